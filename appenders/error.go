@@ -0,0 +1,14 @@
+package appenders
+
+// Error is returned by a Generator to report that the input cannot be
+// represented in its format.  Emitter stops writing when it sees an Error,
+// and reports Err from Close.
+type Error struct {
+	Err error
+}
+
+func (a Error) Append(out []byte) []byte {
+	return out
+}
+
+var _ Appender = Error{}
@@ -1,6 +1,7 @@
 package appenders
 
 import (
+	"fmt"
 	"math/big"
 	"strconv"
 )
@@ -58,3 +59,73 @@ func (a BigFloatText) Append(out []byte) []byte {
 }
 
 var _ Appender = BigFloatText{}
+
+type BigRatText struct {
+	Pointer *big.Rat
+}
+
+func (a BigRatText) Append(out []byte) []byte {
+	out = a.Pointer.Num().Append(out, 10)
+	out = append(out, '/')
+	return a.Pointer.Denom().Append(out, 10)
+}
+
+var _ Appender = BigRatText{}
+
+type BigRatDecimalText struct {
+	Pointer *big.Rat
+}
+
+func (a BigRatDecimalText) Append(out []byte) []byte {
+	digits, ok := TerminatingDigits(a.Pointer)
+	if !ok {
+		panic(fmt.Errorf("%v has no terminating decimal expansion", a.Pointer))
+	}
+	str := a.Pointer.FloatString(digits)
+	return append(out, str...)
+}
+
+var _ Appender = BigRatDecimalText{}
+
+func TerminatingDigits(r *big.Rat) (digits int, ok bool) {
+	if r.IsInt() {
+		return 0, true
+	}
+
+	d := new(big.Int).Set(r.Denom())
+	twos := int(d.TrailingZeroBits())
+	d.Rsh(d, uint(twos))
+
+	var fives int
+	var m big.Int
+	five := big.NewInt(5)
+	for d.Cmp(bigOne) != 0 {
+		d.QuoRem(d, five, &m)
+		if m.Sign() != 0 {
+			return 0, false
+		}
+		fives++
+	}
+	return max(twos, fives), true
+}
+
+// TwosComplement returns the shortest big-endian two's complement encoding
+// of value, as produced by Java's BigInteger.toByteArray.
+func TwosComplement(value *big.Int) []byte {
+	if value.Sign() >= 0 {
+		data := value.Bytes()
+		if len(data) <= 0 || data[0]&0x80 != 0 {
+			data = append([]byte{0}, data...)
+		}
+		return data
+	}
+
+	x := new(big.Int).Neg(value)
+	x.Sub(x, bigOne)
+	n := (x.BitLen() + 8) / 8
+	x.Lsh(bigOne, uint(n*8))
+	x.Add(x, value)
+	return x.FillBytes(make([]byte, n))
+}
+
+var bigOne = big.NewInt(1)
@@ -0,0 +1,15 @@
+package bencode
+
+import (
+	"github.com/chronos-tachyon/go-emitter"
+)
+
+type Bencode struct{}
+
+func (bencode Bencode) NewGenerator() emitter.Generator {
+	g := &Generator{bencode: bencode}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = Bencode{}
@@ -0,0 +1,111 @@
+package bencode
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+func TestBencode(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Input     Value
+		Factory   emitter.GeneratorFactory
+		Expect    string
+		ExpectErr error
+	}
+
+	plain := Bencode{}
+
+	huge, _ := new(big.Int).SetString("-100000000000000000000", 10)
+
+	testData := [...]testCase{
+		{Name: "Int", Input: values.Int(-42), Factory: plain, Expect: "i-42e"},
+		{Name: "Uint", Input: values.Uint(math.MaxUint64), Factory: plain, Expect: "i18446744073709551615e"},
+		{Name: "BigInt", Input: values.BigIntValue{Pointer: huge}, Factory: plain, Expect: "i-100000000000000000000e"},
+		{Name: "Rat", Input: values.BigRatValue{Pointer: big.NewRat(6, 3)}, Factory: plain, Expect: "i2e"},
+		{Name: "String", Input: values.String("spam"), Factory: plain, Expect: "4:spam"},
+		{Name: "Bytes", Input: values.Bytes{0x00, 0xff}, Factory: plain, Expect: "2:\x00\xff"},
+		{Name: "Time", Input: values.Time(time.Unix(1700000000, 5)), Factory: plain, Expect: "i1700000000e"},
+		{
+			Name:    "List",
+			Input:   values.Array{values.String("spam"), values.Int(42), values.Array(nil)},
+			Factory: plain,
+			Expect:  "l4:spami42elee",
+		},
+		{
+			Name: "Dict/Sorted",
+			Input: values.Object{
+				{Key: "spam", Value: values.Array{values.String("a"), values.String("b")}},
+				{Key: "info", Value: values.Object{
+					{Key: "pieces", Value: values.Bytes("xyz")},
+					{Key: "name", Value: values.String("f")},
+					{Key: "length", Value: values.Int(3)},
+				}},
+				{Key: "cow", Value: values.String("moo")},
+				{Key: "Zebra", Value: values.Int(0)},
+			},
+			Factory: plain,
+			Expect:  "d5:Zebrai0e3:cow3:moo4:infod6:lengthi3e4:name1:f6:pieces3:xyze4:spaml1:a1:bee",
+		},
+		{
+			Name:      "Dict/Duplicate",
+			Input:     values.Object{{Key: "a", Value: values.Int(1)}, {Key: "a", Value: values.Int(2)}},
+			Factory:   plain,
+			ExpectErr: fmt.Errorf("bencode dictionary has duplicate key %q", "a"),
+		},
+		{
+			Name:      "Null",
+			Input:     values.Array{values.Null{}},
+			Factory:   plain,
+			ExpectErr: fmt.Errorf("bencode does not support null"),
+		},
+		{
+			Name:      "Bool",
+			Input:     values.Object{{Key: "private", Value: values.Bool(true)}},
+			Factory:   plain,
+			ExpectErr: fmt.Errorf("bencode does not support booleans"),
+		},
+		{
+			Name:      "Float",
+			Input:     values.Float(1),
+			Factory:   plain,
+			ExpectErr: fmt.Errorf("bencode does not support floating-point numbers"),
+		},
+		{
+			Name:      "Fraction",
+			Input:     values.BigRatValue{Pointer: big.NewRat(1, 3)},
+			Factory:   plain,
+			ExpectErr: fmt.Errorf("bencode does not support fractions"),
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, row.Factory.NewGenerator())
+			row.Input.EmitTo(&e)
+			err := e.Close()
+
+			if !reflect.DeepEqual(err, row.ExpectErr) {
+				t.Errorf("wrong error:\n\texpect: %#v\n\tactual: %#v", row.ExpectErr, err)
+				return
+			}
+
+			if actual := buf.String(); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
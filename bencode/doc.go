@@ -0,0 +1,2 @@
+// Package bencode implements the BitTorrent bencoding for Emitter.
+package bencode
@@ -0,0 +1,36 @@
+package bencode
+
+import (
+	"math/big"
+	"strconv"
+)
+
+func appendInt(out []byte, value int64) []byte {
+	out = append(out, 'i')
+	out = strconv.AppendInt(out, value, 10)
+	return append(out, 'e')
+}
+
+func appendUint(out []byte, value uint64) []byte {
+	out = append(out, 'i')
+	out = strconv.AppendUint(out, value, 10)
+	return append(out, 'e')
+}
+
+func appendBigInt(out []byte, value *big.Int) []byte {
+	out = append(out, 'i')
+	out = value.Append(out, 10)
+	return append(out, 'e')
+}
+
+func appendString(out []byte, value string) []byte {
+	out = strconv.AppendUint(out, uint64(len(value)), 10)
+	out = append(out, ':')
+	return append(out, value...)
+}
+
+func appendBytes(out []byte, value []byte) []byte {
+	out = strconv.AppendUint(out, uint64(len(value)), 10)
+	out = append(out, ':')
+	return append(out, value...)
+}
@@ -0,0 +1,246 @@
+package bencode
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+type Appender = appenders.Appender
+
+type entry struct {
+	key   string
+	start int
+	end   int
+}
+
+type frame struct {
+	start   int
+	entries []entry
+}
+
+type Generator struct {
+	bencode Bencode
+	sm      states.Machine
+	frames  []frame
+	buf     []byte
+	scratch []byte
+}
+
+func (g *Generator) Reset() {
+	g.sm.Reset()
+	g.frames = g.frames[:0]
+	g.buf = g.buf[:0]
+}
+
+func (g *Generator) Factory() emitter.GeneratorFactory {
+	return g.bencode
+}
+
+func (g *Generator) Begin() []Appender {
+	g.sm.ExpectRoot()
+	return nil
+}
+
+func (g *Generator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+	return nil
+}
+
+func (g *Generator) NextDocument() []Appender {
+	g.sm.NextDocument()
+	return nil
+}
+
+func (g *Generator) StartObject() []Appender {
+	return g.startContainer('d', states.ObjectFirstKey)
+}
+
+func (g *Generator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	f := g.top()
+	entries := f.entries
+	for index := range entries {
+		if index+1 < len(entries) {
+			entries[index].end = entries[index+1].start
+		} else {
+			entries[index].end = len(g.buf)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+
+	for index := 1; index < len(entries); index++ {
+		if key := entries[index].key; key == entries[index-1].key {
+			g.endContainer()
+			return g.fail(fmt.Errorf("bencode dictionary has duplicate key %q", key))
+		}
+	}
+
+	if len(entries) > 1 {
+		first := f.start + 1
+		g.scratch = g.scratch[:0]
+		for _, e := range entries {
+			g.scratch = append(g.scratch, g.buf[e.start:e.end]...)
+		}
+		copy(g.buf[first:], g.scratch)
+	}
+	return g.endContainer()
+}
+
+func (g *Generator) StartArray() []Appender {
+	return g.startContainer('l', states.ArrayFirstValue)
+}
+
+func (g *Generator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	return g.endContainer()
+}
+
+func (g *Generator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+	f := g.top()
+	f.entries = append(f.entries, entry{key: key, start: len(g.buf)})
+	g.buf = appendString(g.buf, key)
+	g.sm.Next()
+	return nil
+}
+
+func (g *Generator) NullValue() []Appender {
+	return g.unsupported("null")
+}
+
+func (g *Generator) BoolValue(value bool) []Appender {
+	return g.unsupported("booleans")
+}
+
+func (g *Generator) IntValue(value int64) []Appender {
+	return g.value(appendInt(nil, value))
+}
+
+func (g *Generator) UintValue(value uint64) []Appender {
+	return g.value(appendUint(nil, value))
+}
+
+func (g *Generator) BigIntValue(value *big.Int) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	return g.value(appendBigInt(nil, value))
+}
+
+func (g *Generator) NaNValue() []Appender {
+	return g.unsupported("floating-point numbers")
+}
+
+func (g *Generator) InfValue(isNeg bool) []Appender {
+	return g.unsupported("floating-point numbers")
+}
+
+func (g *Generator) FloatValue(value float64) []Appender {
+	return g.unsupported("floating-point numbers")
+}
+
+func (g *Generator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	return g.unsupported("floating-point numbers")
+}
+
+func (g *Generator) BigRatValue(value *big.Rat) []Appender {
+	switch {
+	case value == nil:
+		return g.NullValue()
+	case value.IsInt():
+		return g.BigIntValue(value.Num())
+	default:
+		return g.unsupported("fractions")
+	}
+}
+
+func (g *Generator) ComplexValue(value complex128) []Appender {
+	return g.unsupported("complex numbers")
+}
+
+func (g *Generator) StringValue(value string) []Appender {
+	return g.value(appendString(nil, value))
+}
+
+func (g *Generator) BytesValue(value []byte) []Appender {
+	return g.value(appendBytes(nil, value))
+}
+
+func (g *Generator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *Generator) RuneValue(value rune) []Appender {
+	return g.StringValue(string(value))
+}
+
+func (g *Generator) TimeValue(value time.Time) []Appender {
+	return g.IntValue(value.Unix())
+}
+
+func (g *Generator) startContainer(open byte, next states.State) []Appender {
+	g.sm.ExpectValue()
+	g.sm.Push(next)
+	g.frames = append(g.frames, frame{start: len(g.buf)})
+	g.buf = append(g.buf, open)
+	return nil
+}
+
+func (g *Generator) endContainer() []Appender {
+	n := len(g.frames) - 1
+	g.frames = g.frames[:n]
+	g.sm.Pop()
+	g.sm.Next()
+
+	g.buf = append(g.buf, 'e')
+	if n > 0 {
+		return nil
+	}
+
+	out := make([]byte, len(g.buf))
+	copy(out, g.buf)
+	g.buf = g.buf[:0]
+	return []Appender{appenders.LiteralBytes(out)}
+}
+
+func (g *Generator) value(data []byte) []Appender {
+	g.sm.ExpectValue()
+	g.sm.Next()
+	if len(g.frames) > 0 {
+		g.buf = append(g.buf, data...)
+		return nil
+	}
+	return []Appender{appenders.LiteralBytes(data)}
+}
+
+func (g *Generator) unsupported(what string) []Appender {
+	g.sm.ExpectValue()
+	g.sm.Next()
+	return g.fail(fmt.Errorf("bencode does not support %s", what))
+}
+
+func (g *Generator) fail(err error) []Appender {
+	return []Appender{appenders.Error{Err: err}}
+}
+
+func (g *Generator) top() *frame {
+	return &g.frames[len(g.frames)-1]
+}
+
+var (
+	_ emitter.Generator       = (*Generator)(nil)
+	_ emitter.StreamGenerator = (*Generator)(nil)
+)
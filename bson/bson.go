@@ -0,0 +1,20 @@
+package bson
+
+import (
+	"github.com/chronos-tachyon/go-emitter"
+)
+
+// BSON generates BSON documents.  Big numbers that do not fit exactly in an
+// int64 or a double are written as decimal128 if Decimal128 is set, and are
+// an error otherwise.
+type BSON struct {
+	Decimal128 bool
+}
+
+func (bson BSON) NewGenerator() emitter.Generator {
+	g := &Generator{bson: bson}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = BSON{}
@@ -0,0 +1,159 @@
+package bson
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+func TestBSON(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Input     Value
+		Factory   emitter.GeneratorFactory
+		Expect    string
+		ExpectErr error
+	}
+
+	plain := BSON{}
+	decimal := BSON{Decimal128: true}
+
+	huge, _ := new(big.Int).SetString("100000000000000000000", 10)
+
+	testData := [...]testCase{
+		{
+			Name:    "HelloWorld",
+			Input:   values.Object{{Key: "hello", Value: values.String("world")}},
+			Factory: plain,
+			Expect:  "16000000" + "02" + "68656c6c6f00" + "06000000" + "776f726c6400" + "00",
+		},
+		{
+			Name: "Awesome",
+			Input: values.Object{{Key: "BSON", Value: values.Array{
+				values.String("awesome"),
+				values.Float(5.05),
+				values.Int(1986),
+			}}},
+			Factory: plain,
+			Expect: "31000000" + "04" + "42534f4e00" + "26000000" +
+				"02" + "3000" + "08000000" + "617765736f6d6500" +
+				"01" + "3100" + "3333333333331440" +
+				"10" + "3200" + "c2070000" +
+				"00" + "00",
+		},
+		{
+			Name: "Scalars",
+			Input: values.Object{
+				{Key: "n", Value: values.Null{}},
+				{Key: "b", Value: values.Bool(true)},
+				{Key: "i", Value: values.Int(1 << 40)},
+				{Key: "d", Value: values.Time(time.UnixMilli(1000))},
+				{Key: "x", Value: values.Bytes{0xff}},
+			},
+			Factory: plain,
+			Expect: "2b000000" +
+				"0a" + "6e00" +
+				"08" + "6200" + "01" +
+				"12" + "6900" + "0000000000010000" +
+				"09" + "6400" + "e803000000000000" +
+				"05" + "7800" + "01000000" + "00" + "ff" +
+				"00",
+		},
+		{
+			Name:    "Decimal128/BigInt",
+			Input:   values.Object{{Key: "v", Value: values.BigIntValue{Pointer: huge}}},
+			Factory: decimal,
+			Expect:  "18000000" + "13" + "7600" + "000010632d5ec76b" + "0500000000004030" + "00",
+		},
+		{
+			Name:    "Decimal128/Rat",
+			Input:   values.Object{{Key: "v", Value: values.BigRatValue{Pointer: big.NewRat(-1, 10)}}},
+			Factory: decimal,
+			Expect:  "18000000" + "13" + "7600" + "0100000000000000" + "0000000000003eb0" + "00",
+		},
+		{
+			Name:      "Inexact/BigInt",
+			Input:     values.Object{{Key: "v", Value: values.BigIntValue{Pointer: huge}}},
+			Factory:   plain,
+			ExpectErr: fmt.Errorf("BSON cannot represent integer %v exactly", huge),
+		},
+		{
+			Name:      "Inexact/Rat",
+			Input:     values.Object{{Key: "v", Value: values.BigRatValue{Pointer: big.NewRat(1, 3)}}},
+			Factory:   decimal,
+			ExpectErr: fmt.Errorf("BSON cannot represent rational %v exactly", big.NewRat(1, 3)),
+		},
+		{
+			Name:      "Inexact/Rat/Plain",
+			Input:     values.Object{{Key: "v", Value: values.BigRatValue{Pointer: big.NewRat(-1, 10)}}},
+			Factory:   plain,
+			ExpectErr: fmt.Errorf("BSON cannot represent rational %v exactly", big.NewRat(-1, 10)),
+		},
+		{
+			Name:    "Exact/Rat",
+			Input:   values.Object{{Key: "v", Value: values.BigRatValue{Pointer: big.NewRat(1, 2)}}},
+			Factory: plain,
+			Expect:  "10000000" + "01" + "7600" + "000000000000e03f" + "00",
+		},
+		{
+			Name:      "Inexact/BigFloat",
+			Input:     values.Object{{Key: "v", Value: values.BigFloatValue{Pointer: new(big.Float).SetPrec(200).Quo(big.NewFloat(1), big.NewFloat(3))}}},
+			Factory:   plain,
+			ExpectErr: fmt.Errorf("BSON cannot represent float %v exactly", new(big.Float).SetPrec(200).Quo(big.NewFloat(1), big.NewFloat(3))),
+		},
+		{
+			Name:      "Inexact/Uint",
+			Input:     values.Object{{Key: "v", Value: values.Uint(math.MaxUint64)}},
+			Factory:   plain,
+			ExpectErr: fmt.Errorf("BSON cannot represent integer %v exactly", new(big.Int).SetUint64(math.MaxUint64)),
+		},
+		{
+			Name:    "Decimal128/Uint",
+			Input:   values.Object{{Key: "v", Value: values.Uint(math.MaxUint64)}},
+			Factory: decimal,
+			Expect:  "18000000" + "13" + "7600" + "ffffffffffffffff" + "0000000000004030" + "00",
+		},
+		{
+			Name:      "RootScalar",
+			Input:     values.Int(1),
+			Factory:   plain,
+			ExpectErr: fmt.Errorf("BSON requires a document at the top level"),
+		},
+		{
+			Name:      "RootArray",
+			Input:     values.Array{values.Int(1)},
+			Factory:   plain,
+			ExpectErr: fmt.Errorf("BSON requires a document at the top level"),
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, row.Factory.NewGenerator())
+			row.Input.EmitTo(&e)
+			err := e.Close()
+
+			if !reflect.DeepEqual(err, row.ExpectErr) {
+				t.Errorf("wrong error:\n\texpect: %#v\n\tactual: %#v", row.ExpectErr, err)
+				return
+			}
+
+			if actual := hex.EncodeToString(buf.Bytes()); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %s\n\tactual: %s", row.Expect, actual)
+			}
+		})
+	}
+}
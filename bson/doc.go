@@ -0,0 +1,2 @@
+// Package bson implements the BSON format for Emitter.
+package bson
@@ -0,0 +1,103 @@
+package bson
+
+import (
+	"encoding/binary"
+	"math/big"
+)
+
+const (
+	typeDouble     byte = 0x01
+	typeString     byte = 0x02
+	typeDocument   byte = 0x03
+	typeArray      byte = 0x04
+	typeBinary     byte = 0x05
+	typeBool       byte = 0x08
+	typeDateTime   byte = 0x09
+	typeNull       byte = 0x0a
+	typeInt32      byte = 0x10
+	typeInt64      byte = 0x12
+	typeDecimal128 byte = 0x13
+)
+
+const (
+	subtypeGeneric byte = 0x00
+)
+
+const (
+	decimalExponentBias = 6176
+	decimalMinExponent  = -6176
+	decimalMaxExponent  = 6111
+	decimalMaxDigits    = 34
+)
+
+func appendString(out []byte, str string) []byte {
+	out = binary.LittleEndian.AppendUint32(out, uint32(len(str)+1))
+	out = append(out, str...)
+	return append(out, 0)
+}
+
+func appendBinary(out []byte, subtype byte, data []byte) []byte {
+	out = binary.LittleEndian.AppendUint32(out, uint32(len(data)))
+	out = append(out, subtype)
+	return append(out, data...)
+}
+
+// toDecimal128 returns the BSON encoding of coeff × 10**exp, if it can be
+// represented exactly as an IEEE 754-2008 decimal128 value.
+func toDecimal128(coeff *big.Int, exp int64) ([]byte, bool) {
+	neg := coeff.Sign() < 0
+	c := new(big.Int).Abs(coeff)
+
+	var m big.Int
+	for c.Cmp(decimalMaxCoeff) > 0 || exp < decimalMinExponent {
+		if exp >= decimalMaxExponent {
+			return nil, false
+		}
+		var q big.Int
+		q.QuoRem(c, bigTen, &m)
+		if m.Sign() != 0 {
+			return nil, false
+		}
+		c = &q
+		exp++
+	}
+	for exp > decimalMaxExponent {
+		if c.Sign() == 0 {
+			exp = decimalMaxExponent
+			break
+		}
+		c.Mul(c, bigTen)
+		if c.Cmp(decimalMaxCoeff) > 0 {
+			return nil, false
+		}
+		exp--
+	}
+
+	var data [16]byte
+	c.FillBytes(data[:])
+	hi := binary.BigEndian.Uint64(data[0:8])
+	lo := binary.BigEndian.Uint64(data[8:16])
+	hi |= uint64(exp+decimalExponentBias) << 49
+	if neg {
+		hi |= 1 << 63
+	}
+
+	out := make([]byte, 0, 16)
+	out = binary.LittleEndian.AppendUint64(out, lo)
+	out = binary.LittleEndian.AppendUint64(out, hi)
+	return out, true
+}
+
+// ratToDecimal128 returns the decimal128 encoding of r, if r has a
+// terminating decimal expansion that fits.
+func ratToDecimal128(r *big.Rat, digits int) ([]byte, bool) {
+	scale := new(big.Int).Exp(bigTen, big.NewInt(int64(digits)), nil)
+	coeff := new(big.Int).Mul(r.Num(), scale)
+	coeff.Quo(coeff, r.Denom())
+	return toDecimal128(coeff, -int64(digits))
+}
+
+var (
+	bigTen          = big.NewInt(10)
+	decimalMaxCoeff = new(big.Int).Sub(new(big.Int).Exp(bigTen, big.NewInt(decimalMaxDigits), nil), big.NewInt(1))
+)
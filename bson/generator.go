@@ -0,0 +1,289 @@
+package bson
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+type Appender = appenders.Appender
+
+type frame struct {
+	start   int
+	isArray bool
+	index   uint64
+}
+
+type Generator struct {
+	bson   BSON
+	sm     states.Machine
+	frames []frame
+	buf    []byte
+	key    string
+}
+
+func (g *Generator) Reset() {
+	g.sm.Reset()
+	g.frames = g.frames[:0]
+	g.buf = g.buf[:0]
+	g.key = ""
+}
+
+func (g *Generator) Factory() emitter.GeneratorFactory {
+	return g.bson
+}
+
+func (g *Generator) Begin() []Appender {
+	g.sm.ExpectRoot()
+	return nil
+}
+
+func (g *Generator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+	return nil
+}
+
+func (g *Generator) NextDocument() []Appender {
+	g.sm.NextDocument()
+	return nil
+}
+
+func (g *Generator) StartObject() []Appender {
+	return g.startContainer(typeDocument, states.ObjectFirstKey)
+}
+
+func (g *Generator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	return g.endContainer()
+}
+
+func (g *Generator) StartArray() []Appender {
+	return g.startContainer(typeArray, states.ArrayFirstValue)
+}
+
+func (g *Generator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	return g.endContainer()
+}
+
+func (g *Generator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+	g.key = key
+	g.sm.Next()
+	if strings.IndexByte(key, 0) >= 0 {
+		return g.fail(fmt.Errorf("BSON keys cannot contain NUL: %q", key))
+	}
+	return nil
+}
+
+func (g *Generator) NullValue() []Appender {
+	return g.value(typeNull, nil)
+}
+
+func (g *Generator) BoolValue(value bool) []Appender {
+	if value {
+		return g.value(typeBool, []byte{1})
+	}
+	return g.value(typeBool, []byte{0})
+}
+
+func (g *Generator) IntValue(value int64) []Appender {
+	if value >= math.MinInt32 && value <= math.MaxInt32 {
+		return g.value(typeInt32, binary.LittleEndian.AppendUint32(nil, uint32(value)))
+	}
+	return g.value(typeInt64, binary.LittleEndian.AppendUint64(nil, uint64(value)))
+}
+
+func (g *Generator) UintValue(value uint64) []Appender {
+	if value <= math.MaxInt64 {
+		return g.IntValue(int64(value))
+	}
+	return g.BigIntValue(new(big.Int).SetUint64(value))
+}
+
+func (g *Generator) BigIntValue(value *big.Int) []Appender {
+	switch {
+	case value == nil:
+		return g.NullValue()
+	case value.IsInt64():
+		return g.IntValue(value.Int64())
+	}
+
+	if g.bson.Decimal128 {
+		if data, ok := toDecimal128(value, 0); ok {
+			return g.value(typeDecimal128, data)
+		}
+	}
+	return g.invalid(fmt.Errorf("BSON cannot represent integer %v exactly", value))
+}
+
+func (g *Generator) NaNValue() []Appender {
+	return g.FloatValue(math.NaN())
+}
+
+func (g *Generator) InfValue(isNeg bool) []Appender {
+	if isNeg {
+		return g.FloatValue(math.Inf(-1))
+	}
+	return g.FloatValue(math.Inf(1))
+}
+
+func (g *Generator) FloatValue(value float64) []Appender {
+	return g.value(typeDouble, binary.LittleEndian.AppendUint64(nil, math.Float64bits(value)))
+}
+
+func (g *Generator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+
+	f, acc := value.Float64()
+	if acc == big.Exact {
+		return g.FloatValue(f)
+	}
+	if g.bson.Decimal128 {
+		r, _ := value.Rat(nil)
+		digits, _ := appenders.TerminatingDigits(r)
+		if data, ok := ratToDecimal128(r, digits); ok {
+			return g.value(typeDecimal128, data)
+		}
+	}
+	return g.invalid(fmt.Errorf("BSON cannot represent float %v exactly", value))
+}
+
+func (g *Generator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if value.IsInt() {
+		return g.BigIntValue(value.Num())
+	}
+
+	if g.bson.Decimal128 {
+		if digits, ok := appenders.TerminatingDigits(value); ok {
+			if data, ok := ratToDecimal128(value, digits); ok {
+				return g.value(typeDecimal128, data)
+			}
+		}
+	}
+	if f, exact := value.Float64(); exact {
+		return g.FloatValue(f)
+	}
+	return g.invalid(fmt.Errorf("BSON cannot represent rational %v exactly", value))
+}
+
+func (g *Generator) ComplexValue(value complex128) []Appender {
+	var list []Appender
+	list = append(list, g.StartObject()...)
+	list = append(list, g.Key("re")...)
+	list = append(list, g.FloatValue(real(value))...)
+	list = append(list, g.Key("im")...)
+	list = append(list, g.FloatValue(imag(value))...)
+	list = append(list, g.EndObject()...)
+	return list
+}
+
+func (g *Generator) StringValue(value string) []Appender {
+	return g.value(typeString, appendString(nil, value))
+}
+
+func (g *Generator) BytesValue(value []byte) []Appender {
+	return g.value(typeBinary, appendBinary(nil, subtypeGeneric, value))
+}
+
+func (g *Generator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *Generator) RuneValue(value rune) []Appender {
+	return g.StringValue(string(value))
+}
+
+func (g *Generator) TimeValue(value time.Time) []Appender {
+	return g.value(typeDateTime, binary.LittleEndian.AppendUint64(nil, uint64(value.UnixMilli())))
+}
+
+func (g *Generator) startContainer(typ byte, next states.State) []Appender {
+	g.sm.ExpectValue()
+	isRoot := g.sm.State.In(states.Root)
+
+	var list []Appender
+	switch {
+	case isRoot && typ != typeDocument:
+		list = g.fail(fmt.Errorf("BSON requires a document at the top level"))
+	case !isRoot:
+		g.element(typ)
+	}
+
+	g.sm.Push(next)
+	g.frames = append(g.frames, frame{start: len(g.buf), isArray: typ == typeArray})
+	g.buf = append(g.buf, 0, 0, 0, 0)
+	return list
+}
+
+func (g *Generator) endContainer() []Appender {
+	n := len(g.frames) - 1
+	f := g.frames[n]
+	g.frames = g.frames[:n]
+	g.sm.Pop()
+	g.sm.Next()
+
+	g.buf = append(g.buf, 0)
+	binary.LittleEndian.PutUint32(g.buf[f.start:], uint32(len(g.buf)-f.start))
+	if n > 0 {
+		return nil
+	}
+
+	out := make([]byte, len(g.buf))
+	copy(out, g.buf)
+	g.buf = g.buf[:0]
+	return []Appender{appenders.LiteralBytes(out)}
+}
+
+func (g *Generator) element(typ byte) {
+	g.buf = append(g.buf, typ)
+	if f := &g.frames[len(g.frames)-1]; f.isArray {
+		g.buf = strconv.AppendUint(g.buf, f.index, 10)
+		f.index++
+	} else {
+		g.buf = append(g.buf, g.key...)
+	}
+	g.buf = append(g.buf, 0)
+}
+
+func (g *Generator) value(typ byte, data []byte) []Appender {
+	g.sm.ExpectValue()
+	if g.sm.State.In(states.Root) {
+		g.sm.Next()
+		return g.fail(fmt.Errorf("BSON requires a document at the top level"))
+	}
+
+	g.element(typ)
+	g.buf = append(g.buf, data...)
+	g.sm.Next()
+	return nil
+}
+
+func (g *Generator) invalid(err error) []Appender {
+	g.sm.ExpectValue()
+	g.sm.Next()
+	return g.fail(err)
+}
+
+func (g *Generator) fail(err error) []Appender {
+	return []Appender{appenders.Error{Err: err}}
+}
+
+var (
+	_ emitter.Generator       = (*Generator)(nil)
+	_ emitter.StreamGenerator = (*Generator)(nil)
+)
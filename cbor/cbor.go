@@ -0,0 +1,18 @@
+package cbor
+
+import (
+	"github.com/chronos-tachyon/go-emitter"
+)
+
+type CBOR struct {
+	Definite      bool
+	Deterministic bool
+}
+
+func (cbor CBOR) NewGenerator() emitter.Generator {
+	g := &Generator{cbor: cbor}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = CBOR{}
@@ -0,0 +1,145 @@
+package cbor
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+func bigInt(str string) Value {
+	x, ok := new(big.Int).SetString(str, 10)
+	if !ok {
+		panic(str)
+	}
+	return values.BigIntValue{Pointer: x}
+}
+
+func TestCBOR(t *testing.T) {
+	type testCase struct {
+		Name    string
+		Input   Value
+		Factory emitter.GeneratorFactory
+		Expect  string
+	}
+
+	streaming := CBOR{}
+	definite := CBOR{Definite: true}
+	deterministic := CBOR{Deterministic: true}
+
+	// Most of these come from RFC 8949 Appendix A.
+	testData := [...]testCase{
+		{Name: "Uint/0", Input: values.Uint(0), Factory: streaming, Expect: "00"},
+		{Name: "Uint/23", Input: values.Uint(23), Factory: streaming, Expect: "17"},
+		{Name: "Uint/24", Input: values.Uint(24), Factory: streaming, Expect: "1818"},
+		{Name: "Uint/1000", Input: values.Uint(1000), Factory: streaming, Expect: "1903e8"},
+		{Name: "Uint/1000000", Input: values.Uint(1000000), Factory: streaming, Expect: "1a000f4240"},
+		{Name: "Uint/1e12", Input: values.Uint(1000000000000), Factory: streaming, Expect: "1b000000e8d4a51000"},
+		{Name: "Uint/Max", Input: values.Uint(math.MaxUint64), Factory: streaming, Expect: "1bffffffffffffffff"},
+		{Name: "BigInt/2^64", Input: bigInt("18446744073709551616"), Factory: streaming, Expect: "c249010000000000000000"},
+		{Name: "BigInt/-2^64", Input: bigInt("-18446744073709551616"), Factory: streaming, Expect: "3bffffffffffffffff"},
+		{Name: "BigInt/-2^64-1", Input: bigInt("-18446744073709551617"), Factory: streaming, Expect: "c349010000000000000000"},
+		{Name: "Int/-1", Input: values.Int(-1), Factory: streaming, Expect: "20"},
+		{Name: "Int/-1000", Input: values.Int(-1000), Factory: streaming, Expect: "3903e7"},
+		{Name: "Float/0", Input: values.Float(0), Factory: streaming, Expect: "f90000"},
+		{Name: "Float/-0", Input: values.Float(math.Copysign(0, -1)), Factory: streaming, Expect: "f98000"},
+		{Name: "Float/1", Input: values.Float(1), Factory: streaming, Expect: "f93c00"},
+		{Name: "Float/1.1", Input: values.Float(1.1), Factory: streaming, Expect: "fb3ff199999999999a"},
+		{Name: "Float/65504", Input: values.Float(65504), Factory: streaming, Expect: "f97bff"},
+		{Name: "Float/100000", Input: values.Float(100000), Factory: streaming, Expect: "fa47c35000"},
+		{Name: "Float/MaxFloat32", Input: values.Float(math.MaxFloat32), Factory: streaming, Expect: "fa7f7fffff"},
+		{Name: "Float/1e300", Input: values.Float(1e300), Factory: streaming, Expect: "fb7e37e43c8800759c"},
+		{Name: "Float/SmallestHalf", Input: values.Float(5.960464477539063e-8), Factory: streaming, Expect: "f90001"},
+		{Name: "Float/SmallestNormalHalf", Input: values.Float(0.00006103515625), Factory: streaming, Expect: "f90400"},
+		{Name: "Float/-4", Input: values.Float(-4), Factory: streaming, Expect: "f9c400"},
+		{Name: "Float/Inf", Input: values.Float(math.Inf(1)), Factory: streaming, Expect: "f97c00"},
+		{Name: "Float/NaN", Input: values.Float(math.NaN()), Factory: streaming, Expect: "f97e00"},
+		{Name: "Float/-Inf", Input: values.Float(math.Inf(-1)), Factory: streaming, Expect: "f9fc00"},
+		{Name: "BigFloat/1.5", Input: values.BigFloatValue{Pointer: big.NewFloat(1.5)}, Factory: streaming, Expect: "c5822003"},
+		{Name: "Rat/273.15", Input: values.BigRatValue{Pointer: big.NewRat(27315, 100)}, Factory: streaming, Expect: "c48221196ab3"},
+		{Name: "Rat/1/3", Input: values.BigRatValue{Pointer: big.NewRat(1, 3)}, Factory: streaming, Expect: "d81e820103"},
+		{Name: "Complex", Input: values.Complex(complex(1, -4)), Factory: streaming, Expect: "d9a7f882f93c00f9c400"},
+		{Name: "Time/Epoch", Input: values.Time(time.Unix(1363896240, 0)), Factory: streaming, Expect: "c11a514b67b0"},
+		{Name: "Time/Text", Input: values.Time(time.Date(2013, 3, 21, 20, 4, 0, 500000000, time.UTC)), Factory: streaming, Expect: "c0" + "76" + hex.EncodeToString([]byte("2013-03-21T20:04:00.5Z"))},
+		{Name: "False", Input: values.Bool(false), Factory: streaming, Expect: "f4"},
+		{Name: "True", Input: values.Bool(true), Factory: streaming, Expect: "f5"},
+		{Name: "Null", Input: values.Null{}, Factory: streaming, Expect: "f6"},
+		{Name: "Bytes", Input: values.Bytes{1, 2, 3, 4}, Factory: streaming, Expect: "4401020304"},
+		{Name: "String", Input: values.String("IETF"), Factory: streaming, Expect: "6449455446"},
+		{Name: "String/Unicode", Input: values.String("ü"), Factory: streaming, Expect: "62c3bc"},
+		{
+			Name:    "Streaming/Array",
+			Input:   values.Array{values.Int(1), values.Array{values.Int(2), values.Int(3)}, values.Array{values.Int(4), values.Int(5)}},
+			Factory: streaming,
+			Expect:  "9f019f0203ff9f0405ffff",
+		},
+		{
+			Name: "Streaming/Object",
+			Input: values.Object{
+				{Key: "a", Value: values.Int(1)},
+				{Key: "b", Value: values.Array{values.Int(2), values.Int(3)}},
+			},
+			Factory: streaming,
+			Expect:  "bf61610161629f0203ffff",
+		},
+		{
+			Name:    "Definite/Array",
+			Input:   values.Array{values.Int(1), values.Array{values.Int(2), values.Int(3)}, values.Array{values.Int(4), values.Int(5)}},
+			Factory: definite,
+			Expect:  "8301820203820405",
+		},
+		{
+			Name:    "Definite/Empty",
+			Input:   values.Array{values.Array(nil), values.Object(nil)},
+			Factory: definite,
+			Expect:  "8280a0",
+		},
+		{
+			Name: "Definite/Map",
+			Input: values.Map{
+				{Key: values.Int(1), Value: values.Int(2)},
+				{Key: values.Int(3), Value: values.Int(4)},
+			},
+			Factory: definite,
+			Expect:  "a201020304",
+		},
+		{
+			Name: "Deterministic/Map",
+			Input: values.Map{
+				{Key: values.String("b"), Value: values.Int(1)},
+				{Key: values.String("a"), Value: values.Object{
+					{Key: "z", Value: values.Int(2)},
+					{Key: "y", Value: values.Int(3)},
+				}},
+				{Key: values.Int(10), Value: values.Int(4)},
+			},
+			Factory: deterministic,
+			Expect:  "a30a046161a2617903617a02616201",
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, row.Factory.NewGenerator())
+			row.Input.EmitTo(&e)
+			if err := e.Close(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if actual := hex.EncodeToString(buf.Bytes()); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %s\n\tactual: %s", row.Expect, actual)
+			}
+		})
+	}
+}
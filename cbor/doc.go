@@ -0,0 +1,2 @@
+// Package cbor implements the CBOR (RFC 8949) format for Emitter.
+package cbor
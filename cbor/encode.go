@@ -0,0 +1,176 @@
+package cbor
+
+import (
+	"math"
+	"math/big"
+)
+
+const (
+	majorUint   byte = 0
+	majorNegInt byte = 1
+	majorBytes  byte = 2
+	majorText   byte = 3
+	majorArray  byte = 4
+	majorMap    byte = 5
+	majorTag    byte = 6
+	majorSimple byte = 7
+)
+
+const (
+	tagDateTime    = 0
+	tagEpochTime   = 1
+	tagPosBignum   = 2
+	tagNegBignum   = 3
+	tagDecimalFrac = 4
+	tagBigFloat    = 5
+	tagRational    = 30
+	tagComplex     = 43000
+)
+
+const (
+	indefiniteLength = 31
+	breakCode        = 0xff
+	simpleFalse      = 0xf4
+	simpleTrue       = 0xf5
+	simpleNull       = 0xf6
+	float16Code      = 0xf9
+	float32Code      = 0xfa
+	float64Code      = 0xfb
+)
+
+const (
+	canonicalHalfNaN  = 0x7e00
+	halfPosInfinity   = 0x7c00
+	halfNegInfinity   = 0xfc00
+	halfMaxExponent   = 15
+	halfMinExponent   = -14
+	halfMantissaBits  = 10
+	halfSubnormalBits = 24
+)
+
+func appendHead(out []byte, major byte, arg uint64) []byte {
+	major <<= 5
+	switch {
+	case arg < 24:
+		return append(out, major|byte(arg))
+	case arg <= math.MaxUint8:
+		return append(out, major|24, byte(arg))
+	case arg <= math.MaxUint16:
+		return append(out, major|25, byte(arg>>8), byte(arg))
+	case arg <= math.MaxUint32:
+		return append(out, major|26, byte(arg>>24), byte(arg>>16), byte(arg>>8), byte(arg))
+	default:
+		return append(out, major|27,
+			byte(arg>>56), byte(arg>>48), byte(arg>>40), byte(arg>>32),
+			byte(arg>>24), byte(arg>>16), byte(arg>>8), byte(arg))
+	}
+}
+
+func appendInt(out []byte, value int64) []byte {
+	if value < 0 {
+		return appendHead(out, majorNegInt, uint64(-1-value))
+	}
+	return appendHead(out, majorUint, uint64(value))
+}
+
+func appendBigInt(out []byte, value *big.Int) []byte {
+	if value.Sign() >= 0 {
+		if value.IsUint64() {
+			return appendHead(out, majorUint, value.Uint64())
+		}
+		out = appendHead(out, majorTag, tagPosBignum)
+		return appendBytes(out, majorBytes, value.Bytes())
+	}
+
+	n := new(big.Int).Neg(value)
+	n.Sub(n, bigOne)
+	if n.IsUint64() {
+		return appendHead(out, majorNegInt, n.Uint64())
+	}
+	out = appendHead(out, majorTag, tagNegBignum)
+	return appendBytes(out, majorBytes, n.Bytes())
+}
+
+func appendBytes(out []byte, major byte, data []byte) []byte {
+	out = appendHead(out, major, uint64(len(data)))
+	return append(out, data...)
+}
+
+func appendString(out []byte, str string) []byte {
+	out = appendHead(out, majorText, uint64(len(str)))
+	return append(out, str...)
+}
+
+func appendFloat(out []byte, value float64) []byte {
+	if math.IsNaN(value) {
+		return appendHalf(out, canonicalHalfNaN)
+	}
+	if bits, ok := toHalf(value); ok {
+		return appendHalf(out, bits)
+	}
+	if f32 := float32(value); float64(f32) == value {
+		bits := math.Float32bits(f32)
+		return append(out, float32Code, byte(bits>>24), byte(bits>>16), byte(bits>>8), byte(bits))
+	}
+	bits := math.Float64bits(value)
+	return append(out, float64Code,
+		byte(bits>>56), byte(bits>>48), byte(bits>>40), byte(bits>>32),
+		byte(bits>>24), byte(bits>>16), byte(bits>>8), byte(bits))
+}
+
+func appendHalf(out []byte, bits uint16) []byte {
+	return append(out, float16Code, byte(bits>>8), byte(bits))
+}
+
+// toHalf returns the IEEE 754 binary16 encoding of value, if value can be
+// represented in binary16 without loss.
+func toHalf(value float64) (uint16, bool) {
+	var sign uint16
+	if math.Signbit(value) {
+		sign = 0x8000
+		value = -value
+	}
+
+	switch {
+	case value == 0:
+		return sign, true
+	case math.IsInf(value, 0):
+		return sign | halfPosInfinity, true
+	}
+
+	frac, exp := math.Frexp(value)
+	exp--
+	if exp > halfMaxExponent {
+		return 0, false
+	}
+
+	if exp < halfMinExponent {
+		m := math.Ldexp(value, halfSubnormalBits)
+		if m != math.Trunc(m) || m >= 1<<halfMantissaBits {
+			return 0, false
+		}
+		return sign | uint16(m), true
+	}
+
+	m := math.Ldexp(frac*2-1, halfMantissaBits)
+	if m != math.Trunc(m) {
+		return 0, false
+	}
+	return sign | uint16(exp-halfMinExponent+1)<<halfMantissaBits | uint16(m), true
+}
+
+// mantExp returns (m, e) such that value == m × 2**e, with m odd or zero.
+func mantExp(value *big.Float) (*big.Int, int64) {
+	if value.Sign() == 0 {
+		return new(big.Int), 0
+	}
+
+	prec := int(value.MinPrec())
+	var mant big.Float
+	exp := int64(value.MantExp(&mant)) - int64(prec)
+	mant.SetMantExp(&mant, prec)
+	m, _ := mant.Int(nil)
+	return m, exp
+}
+
+var bigOne = big.NewInt(1)
@@ -0,0 +1,330 @@
+package cbor
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+type Appender = appenders.Appender
+
+type entry struct {
+	start  int
+	keyEnd int
+}
+
+type frame struct {
+	isMap   bool
+	count   uint64
+	buf     []byte
+	entries []entry
+}
+
+type Generator struct {
+	cbor   CBOR
+	sm     states.Machine
+	frames []frame
+}
+
+func (g *Generator) Reset() {
+	g.sm.Reset()
+	g.frames = g.frames[:0]
+}
+
+func (g *Generator) Factory() emitter.GeneratorFactory {
+	return g.cbor
+}
+
+func (g *Generator) Begin() []Appender {
+	g.sm.ExpectRoot()
+	return nil
+}
+
+func (g *Generator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+	return nil
+}
+
+func (g *Generator) NextDocument() []Appender {
+	g.sm.NextDocument()
+	return nil
+}
+
+func (g *Generator) StartObject() []Appender {
+	return g.startContainer(true, states.ObjectFirstKey)
+}
+
+func (g *Generator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	return g.endContainer()
+}
+
+func (g *Generator) StartArray() []Appender {
+	return g.startContainer(false, states.ArrayFirstValue)
+}
+
+func (g *Generator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	return g.endContainer()
+}
+
+func (g *Generator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+	g.startEntry()
+	list := g.write(appendString(nil, key))
+	g.endEntryKey()
+	g.sm.Next()
+	return list
+}
+
+func (g *Generator) StartKey() []Appender {
+	g.sm.StartKey()
+	g.startEntry()
+	return nil
+}
+
+func (g *Generator) EndKey() []Appender {
+	g.sm.EndKey()
+	g.endEntryKey()
+	return nil
+}
+
+func (g *Generator) NullValue() []Appender {
+	return g.value([]byte{simpleNull})
+}
+
+func (g *Generator) BoolValue(value bool) []Appender {
+	if value {
+		return g.value([]byte{simpleTrue})
+	}
+	return g.value([]byte{simpleFalse})
+}
+
+func (g *Generator) IntValue(value int64) []Appender {
+	return g.value(appendInt(nil, value))
+}
+
+func (g *Generator) UintValue(value uint64) []Appender {
+	return g.value(appendHead(nil, majorUint, value))
+}
+
+func (g *Generator) BigIntValue(value *big.Int) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	return g.value(appendBigInt(nil, value))
+}
+
+func (g *Generator) NaNValue() []Appender {
+	return g.value(appendHalf(nil, canonicalHalfNaN))
+}
+
+func (g *Generator) InfValue(isNeg bool) []Appender {
+	if isNeg {
+		return g.value(appendHalf(nil, halfNegInfinity))
+	}
+	return g.value(appendHalf(nil, halfPosInfinity))
+}
+
+func (g *Generator) FloatValue(value float64) []Appender {
+	return g.value(appendFloat(nil, value))
+}
+
+func (g *Generator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if value.IsInf() {
+		return g.InfValue(value.Signbit())
+	}
+
+	m, e := mantExp(value)
+	var out []byte
+	out = appendHead(out, majorTag, tagBigFloat)
+	out = appendHead(out, majorArray, 2)
+	out = appendInt(out, e)
+	out = appendBigInt(out, m)
+	return g.value(out)
+}
+
+func (g *Generator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if value.IsInt() {
+		return g.value(appendBigInt(nil, value.Num()))
+	}
+
+	var out []byte
+	if digits, ok := appenders.TerminatingDigits(value); ok {
+		scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+		m := new(big.Int).Mul(value.Num(), scale)
+		m.Quo(m, value.Denom())
+		out = appendHead(out, majorTag, tagDecimalFrac)
+		out = appendHead(out, majorArray, 2)
+		out = appendInt(out, -int64(digits))
+		out = appendBigInt(out, m)
+		return g.value(out)
+	}
+
+	out = appendHead(out, majorTag, tagRational)
+	out = appendHead(out, majorArray, 2)
+	out = appendBigInt(out, value.Num())
+	out = appendBigInt(out, value.Denom())
+	return g.value(out)
+}
+
+func (g *Generator) ComplexValue(value complex128) []Appender {
+	var out []byte
+	out = appendHead(out, majorTag, tagComplex)
+	out = appendHead(out, majorArray, 2)
+	out = appendFloat(out, real(value))
+	out = appendFloat(out, imag(value))
+	return g.value(out)
+}
+
+func (g *Generator) StringValue(value string) []Appender {
+	return g.value(appendString(nil, value))
+}
+
+func (g *Generator) BytesValue(value []byte) []Appender {
+	return g.value(appendBytes(nil, majorBytes, value))
+}
+
+func (g *Generator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *Generator) RuneValue(value rune) []Appender {
+	return g.StringValue(string(value))
+}
+
+func (g *Generator) TimeValue(value time.Time) []Appender {
+	var out []byte
+	if value.Nanosecond() == 0 {
+		out = appendHead(out, majorTag, tagEpochTime)
+		out = appendInt(out, value.Unix())
+		return g.value(out)
+	}
+	out = appendHead(out, majorTag, tagDateTime)
+	out = appendString(out, value.Format(time.RFC3339Nano))
+	return g.value(out)
+}
+
+func (g *Generator) definite() bool {
+	return g.cbor.Definite || g.cbor.Deterministic
+}
+
+func (g *Generator) startContainer(isMap bool, next states.State) []Appender {
+	g.beginValue()
+	g.sm.Push(next)
+
+	var list []Appender
+	if !g.definite() {
+		major := majorArray
+		if isMap {
+			major = majorMap
+		}
+		list = g.write([]byte{major<<5 | indefiniteLength})
+	}
+	g.frames = append(g.frames, frame{isMap: isMap})
+	return list
+}
+
+func (g *Generator) endContainer() []Appender {
+	n := len(g.frames) - 1
+	f := g.frames[n]
+	g.frames = g.frames[:n]
+	g.sm.Pop()
+	g.sm.Next()
+
+	if !g.definite() {
+		return g.write([]byte{breakCode})
+	}
+
+	major := majorArray
+	if f.isMap {
+		major = majorMap
+	}
+	out := appendHead(nil, major, f.count)
+	if f.isMap && g.cbor.Deterministic {
+		out = appendSorted(out, f.buf, f.entries)
+	} else {
+		out = append(out, f.buf...)
+	}
+	return g.write(out)
+}
+
+func (g *Generator) startEntry() {
+	f := &g.frames[len(g.frames)-1]
+	f.count++
+	if g.cbor.Deterministic {
+		f.entries = append(f.entries, entry{start: len(f.buf)})
+	}
+}
+
+func (g *Generator) endEntryKey() {
+	f := &g.frames[len(g.frames)-1]
+	if g.cbor.Deterministic {
+		f.entries[len(f.entries)-1].keyEnd = len(f.buf)
+	}
+}
+
+func (g *Generator) beginValue() {
+	g.sm.ExpectValue()
+	if g.sm.State.In(states.ArrayFirstValue, states.ArrayNextValue) {
+		g.frames[len(g.frames)-1].count++
+	}
+}
+
+func (g *Generator) value(data []byte) []Appender {
+	g.beginValue()
+	list := g.write(data)
+	g.sm.Next()
+	return list
+}
+
+func (g *Generator) write(data []byte) []Appender {
+	if n := len(g.frames); n > 0 && g.definite() {
+		g.frames[n-1].buf = append(g.frames[n-1].buf, data...)
+		return nil
+	}
+	return []Appender{appenders.LiteralBytes(data)}
+}
+
+func appendSorted(out []byte, buf []byte, entries []entry) []byte {
+	type span struct {
+		key   []byte
+		entry []byte
+	}
+
+	spans := make([]span, len(entries))
+	for index, e := range entries {
+		end := len(buf)
+		if index+1 < len(entries) {
+			end = entries[index+1].start
+		}
+		spans[index] = span{key: buf[e.start:e.keyEnd], entry: buf[e.start:end]}
+	}
+
+	sort.SliceStable(spans, func(i, j int) bool {
+		return bytes.Compare(spans[i].key, spans[j].key) < 0
+	})
+
+	for _, s := range spans {
+		out = append(out, s.entry...)
+	}
+	return out
+}
+
+var (
+	_ emitter.Generator       = (*Generator)(nil)
+	_ emitter.KeyGenerator    = (*Generator)(nil)
+	_ emitter.StreamGenerator = (*Generator)(nil)
+)
@@ -0,0 +1,78 @@
+package csv
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/chronos-tachyon/go-emitter/appenders"
+)
+
+type Appender = appenders.Appender
+
+type FieldAppender struct {
+	Value     string
+	Separator rune
+}
+
+func (a FieldAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a FieldAppender) Append(out []byte) []byte {
+	if !a.needsQuotes() {
+		return append(out, a.Value...)
+	}
+
+	out = append(out, '"')
+	for i := 0; i < len(a.Value); i++ {
+		ch := a.Value[i]
+		if ch == '"' {
+			out = append(out, '"')
+		}
+		out = append(out, ch)
+	}
+	out = append(out, '"')
+	return out
+}
+
+func (a FieldAppender) needsQuotes() bool {
+	if strings.ContainsAny(a.Value, "\"\r\n") {
+		return true
+	}
+	return strings.ContainsRune(a.Value, a.Separator)
+}
+
+var (
+	_ fmt.Stringer = FieldAppender{}
+	_ Appender     = FieldAppender{}
+)
+
+type RecordAppender struct {
+	Fields    []string
+	Separator rune
+	UseCRLF   bool
+}
+
+func (a RecordAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a RecordAppender) Append(out []byte) []byte {
+	for index, field := range a.Fields {
+		if index > 0 {
+			out = utf8.AppendRune(out, a.Separator)
+		}
+		out = FieldAppender{Value: field, Separator: a.Separator}.Append(out)
+	}
+	if a.UseCRLF {
+		out = append(out, '\r')
+	}
+	out = append(out, '\n')
+	return out
+}
+
+var (
+	_ fmt.Stringer = RecordAppender{}
+	_ Appender     = RecordAppender{}
+)
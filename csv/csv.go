@@ -0,0 +1,88 @@
+package csv
+
+import (
+	"encoding"
+	"fmt"
+
+	"github.com/chronos-tachyon/go-emitter"
+)
+
+type CSV struct {
+	Columns    []string
+	Separator  rune
+	Nested     Nested
+	Null       string
+	UseCRLF    bool
+	OmitHeader bool
+}
+
+func (csv CSV) NewGenerator() emitter.Generator {
+	g := &Generator{csv: csv}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = CSV{}
+
+type Nested byte
+
+const (
+	NestedJSON Nested = iota
+	NestedFlatten
+)
+
+const nestedSize = 2
+
+var nestedGoNames = [nestedSize]string{
+	"csv.NestedJSON",
+	"csv.NestedFlatten",
+}
+
+var nestedNames = [nestedSize]string{
+	"json",
+	"flatten",
+}
+
+func (n Nested) IsValid() bool {
+	return n < nestedSize
+}
+
+func (n Nested) GoString() string {
+	if n.IsValid() {
+		return nestedGoNames[n]
+	}
+	return fmt.Sprintf("csv.Nested(%d)", uint(n))
+}
+
+func (n Nested) String() string {
+	if n.IsValid() {
+		return nestedNames[n]
+	}
+	return fmt.Sprintf("%%!ERR[invalid csv.Nested %d]", uint(n))
+}
+
+func (n Nested) MarshalText() ([]byte, error) {
+	return []byte(n.String()), nil
+}
+
+func (n *Nested) Parse(input string) error {
+	for index, name := range nestedNames {
+		if input == name {
+			*n = Nested(index)
+			return nil
+		}
+	}
+	*n = ^Nested(0)
+	return fmt.Errorf("failed to parse %q as csv.Nested", input)
+}
+
+func (n *Nested) UnmarshalText(input []byte) error {
+	return n.Parse(string(input))
+}
+
+var (
+	_ fmt.GoStringer           = Nested(0)
+	_ fmt.Stringer             = Nested(0)
+	_ encoding.TextMarshaler   = Nested(0)
+	_ encoding.TextUnmarshaler = (*Nested)(nil)
+)
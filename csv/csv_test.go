@@ -0,0 +1,118 @@
+package csv
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+func TestCSV(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Input     Value
+		Factory   emitter.GeneratorFactory
+		Expect    string
+		ExpectErr error
+	}
+
+	kRecordsValue := values.Array{
+		values.Object{
+			{Key: "id", Value: values.Int(1)},
+			{Key: "name", Value: values.String("Smith, \"Jo\"")},
+			{Key: "tags", Value: values.Array{values.String("a"), values.Object{{Key: "b", Value: values.Null{}}}}},
+			{Key: "note", Value: values.Null{}},
+		},
+		values.Object{
+			{Key: "id", Value: values.Int(2)},
+			{Key: "name", Value: values.String("line\nbreak")},
+			{Key: "tags", Value: values.Array(nil)},
+		},
+	}
+
+	testData := [...]testCase{
+		{
+			Name:    "JSON",
+			Input:   kRecordsValue,
+			Factory: CSV{},
+			Expect: "id,name,tags,note\n" +
+				"1,\"Smith, \"\"Jo\"\"\",\"[\"\"a\"\",{\"\"b\"\":null}]\",\n" +
+				"2,\"line\nbreak\",[],\n",
+		},
+		{
+			Name:    "Flatten",
+			Input:   kRecordsValue,
+			Factory: CSV{Nested: NestedFlatten, Null: "NULL", Columns: []string{"id", "name", "note", "tags.0", "tags.1.b"}},
+			Expect: "id,name,note,tags.0,tags.1.b\n" +
+				"1,\"Smith, \"\"Jo\"\"\",NULL,a,NULL\n" +
+				"2,\"line\nbreak\",NULL,NULL,NULL\n",
+		},
+		{
+			Name: "MissingColumn",
+			Input: values.Array{
+				values.Object{{Key: "a", Value: values.Int(1)}, {Key: "b", Value: values.String("")}},
+				values.Object{{Key: "a", Value: values.Int(2)}},
+			},
+			Factory: CSV{Null: `\N`, Columns: []string{"a", "b"}},
+			Expect:  "a,b\n1,\n2,\\N\n",
+		},
+		{
+			Name:      "TSV",
+			Input:     kRecordsValue,
+			Factory:   CSV{Separator: '\t', UseCRLF: true, Columns: []string{"name", "id"}, OmitHeader: true},
+			ExpectErr: fmt.Errorf("CSV field %q is not one of the columns", "tags"),
+		},
+		{
+			Name:    "Empty",
+			Input:   values.Array(nil),
+			Factory: CSV{Columns: []string{"a", "b"}},
+			Expect:  "a,b\n",
+		},
+		{
+			Name:      "NotArray",
+			Input:     values.Object(nil),
+			Factory:   CSV{},
+			ExpectErr: fmt.Errorf("CSV requires an array of records at the top level"),
+		},
+		{
+			Name:      "NotRecord",
+			Input:     values.Array{values.Int(1)},
+			Factory:   CSV{},
+			ExpectErr: fmt.Errorf("CSV records must be objects"),
+		},
+		{
+			Name: "Flatten/Collision",
+			Input: values.Array{values.Object{
+				{Key: "a.b", Value: values.Int(1)},
+				{Key: "a", Value: values.Object{{Key: "b", Value: values.Int(2)}}},
+			}},
+			Factory:   CSV{Nested: NestedFlatten},
+			ExpectErr: fmt.Errorf("CSV record has more than one field named %q", "a.b"),
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, row.Factory.NewGenerator())
+			row.Input.EmitTo(&e)
+			err := e.Close()
+
+			if !reflect.DeepEqual(err, row.ExpectErr) {
+				t.Errorf("wrong error:\n\texpect: %#v\n\tactual: %#v", row.ExpectErr, err)
+				return
+			}
+
+			if actual := buf.String(); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
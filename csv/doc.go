@@ -0,0 +1,2 @@
+// Package csv implements the CSV and TSV formats for Emitter.
+package csv
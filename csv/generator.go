@@ -0,0 +1,408 @@
+package csv
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/json"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+type kind byte
+
+const (
+	kindScalar kind = iota
+	kindObject
+	kindArray
+)
+
+type frame struct {
+	isArray bool
+	index   uint64
+	name    string
+}
+
+type Generator struct {
+	csv     CSV
+	sm      states.Machine
+	frames  []frame
+	columns []string
+	index   map[string]int
+	names   []string
+	values  []string
+	inner   emitter.Generator
+	nesting bool
+	depth   int
+	buf     []byte
+}
+
+func (g *Generator) Reset() {
+	g.sm.Reset()
+	g.frames = g.frames[:0]
+	g.columns = nil
+	g.index = nil
+	g.names = g.names[:0]
+	g.values = g.values[:0]
+	if g.inner == nil {
+		g.inner = json.JSON{}.NewGenerator()
+	}
+	g.inner.Reset()
+	g.nesting = false
+	g.depth = 0
+	g.buf = g.buf[:0]
+}
+
+func (g *Generator) Factory() emitter.GeneratorFactory {
+	return g.csv
+}
+
+func (g *Generator) Begin() []Appender {
+	g.sm.ExpectRoot()
+	return nil
+}
+
+func (g *Generator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+	return nil
+}
+
+func (g *Generator) StartObject() []Appender {
+	return g.startContainer(kindObject, states.ObjectFirstKey)
+}
+
+func (g *Generator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	return g.endContainer(kindObject)
+}
+
+func (g *Generator) StartArray() []Appender {
+	return g.startContainer(kindArray, states.ArrayFirstValue)
+}
+
+func (g *Generator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	return g.endContainer(kindArray)
+}
+
+func (g *Generator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+	if g.nesting {
+		g.capture(g.inner.Key(key))
+	} else {
+		g.top().name = key
+	}
+	g.sm.Next()
+	return nil
+}
+
+func (g *Generator) NullValue() []Appender {
+	return g.scalar(g.csv.Null, func(j emitter.Generator) []Appender {
+		return j.NullValue()
+	})
+}
+
+func (g *Generator) BoolValue(value bool) []Appender {
+	return g.scalar(strconv.FormatBool(value), func(j emitter.Generator) []Appender {
+		return j.BoolValue(value)
+	})
+}
+
+func (g *Generator) IntValue(value int64) []Appender {
+	return g.scalar(strconv.FormatInt(value, 10), func(j emitter.Generator) []Appender {
+		return j.IntValue(value)
+	})
+}
+
+func (g *Generator) UintValue(value uint64) []Appender {
+	return g.scalar(strconv.FormatUint(value, 10), func(j emitter.Generator) []Appender {
+		return j.UintValue(value)
+	})
+}
+
+func (g *Generator) BigIntValue(value *big.Int) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	return g.scalar(value.String(), func(j emitter.Generator) []Appender {
+		return j.BigIntValue(value)
+	})
+}
+
+func (g *Generator) NaNValue() []Appender {
+	return g.scalar("NaN", func(j emitter.Generator) []Appender {
+		return j.NaNValue()
+	})
+}
+
+func (g *Generator) InfValue(isNeg bool) []Appender {
+	str := "+Inf"
+	if isNeg {
+		str = "-Inf"
+	}
+	return g.scalar(str, func(j emitter.Generator) []Appender {
+		return j.InfValue(isNeg)
+	})
+}
+
+func (g *Generator) FloatValue(value float64) []Appender {
+	str := string(appenders.FloatText(value).Append(nil))
+	return g.scalar(str, func(j emitter.Generator) []Appender {
+		return j.FloatValue(value)
+	})
+}
+
+func (g *Generator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	str := string(appenders.BigFloatText{Pointer: value}.Append(nil))
+	return g.scalar(str, func(j emitter.Generator) []Appender {
+		return j.BigFloatValue(value)
+	})
+}
+
+func (g *Generator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	var str string
+	if _, ok := appenders.TerminatingDigits(value); ok {
+		str = string(appenders.BigRatDecimalText{Pointer: value}.Append(nil))
+	} else {
+		str = string(appenders.BigRatText{Pointer: value}.Append(nil))
+	}
+	return g.scalar(str, func(j emitter.Generator) []Appender {
+		return j.BigRatValue(value)
+	})
+}
+
+func (g *Generator) ComplexValue(value complex128) []Appender {
+	var list []Appender
+	list = append(list, g.StartObject()...)
+	list = append(list, g.Key("re")...)
+	list = append(list, g.floatValue(real(value))...)
+	list = append(list, g.Key("im")...)
+	list = append(list, g.floatValue(imag(value))...)
+	list = append(list, g.EndObject()...)
+	return list
+}
+
+func (g *Generator) StringValue(value string) []Appender {
+	return g.scalar(value, func(j emitter.Generator) []Appender {
+		return j.StringValue(value)
+	})
+}
+
+func (g *Generator) BytesValue(value []byte) []Appender {
+	return g.scalar(base64.StdEncoding.EncodeToString(value), func(j emitter.Generator) []Appender {
+		return j.BytesValue(value)
+	})
+}
+
+func (g *Generator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *Generator) RuneValue(value rune) []Appender {
+	return g.StringValue(string(value))
+}
+
+func (g *Generator) TimeValue(value time.Time) []Appender {
+	return g.StringValue(value.Format(time.RFC3339Nano))
+}
+
+func (g *Generator) floatValue(value float64) []Appender {
+	switch {
+	case math.IsNaN(value):
+		return g.NaNValue()
+	case math.IsInf(value, 0):
+		return g.InfValue(value < 0)
+	default:
+		return g.FloatValue(value)
+	}
+}
+
+func (g *Generator) startContainer(k kind, next states.State) []Appender {
+	list := g.beginValue(k)
+
+	switch {
+	case g.nesting:
+		g.capture(g.startInner(k))
+	case len(g.frames) >= 2 && g.csv.Nested == NestedJSON:
+		g.nesting = true
+		g.depth = len(g.frames)
+		g.capture(g.inner.Begin())
+		g.capture(g.startInner(k))
+	}
+
+	g.sm.Push(next)
+	g.frames = append(g.frames, frame{isArray: k == kindArray})
+
+	switch len(g.frames) {
+	case 1:
+		if len(g.csv.Columns) > 0 {
+			list = append(list, g.header(g.csv.Columns)...)
+		}
+	case 2:
+		g.names = g.names[:0]
+		g.values = g.values[:0]
+	}
+	return list
+}
+
+func (g *Generator) endContainer(k kind) []Appender {
+	n := len(g.frames) - 1
+	g.frames = g.frames[:n]
+	g.sm.Pop()
+
+	var list []Appender
+	switch {
+	case g.nesting:
+		g.capture(g.endInner(k))
+		if n == g.depth {
+			g.field(string(g.buf))
+			g.buf = g.buf[:0]
+			g.inner.Reset()
+			g.nesting = false
+		}
+	case n == 1:
+		list = g.record()
+	}
+
+	g.sm.Next()
+	return list
+}
+
+func (g *Generator) startInner(k kind) []Appender {
+	if k == kindArray {
+		return g.inner.StartArray()
+	}
+	return g.inner.StartObject()
+}
+
+func (g *Generator) endInner(k kind) []Appender {
+	if k == kindArray {
+		return g.inner.EndArray()
+	}
+	return g.inner.EndObject()
+}
+
+func (g *Generator) beginValue(k kind) []Appender {
+	g.sm.ExpectValue()
+	switch len(g.frames) {
+	case 0:
+		if k != kindArray {
+			return g.fail(fmt.Errorf("CSV requires an array of records at the top level"))
+		}
+	case 1:
+		if k != kindObject {
+			return g.fail(fmt.Errorf("CSV records must be objects"))
+		}
+	default:
+		if f := g.top(); f.isArray {
+			f.name = strconv.FormatUint(f.index, 10)
+			f.index++
+		}
+	}
+	return nil
+}
+
+func (g *Generator) scalar(str string, fn func(emitter.Generator) []Appender) []Appender {
+	list := g.beginValue(kindScalar)
+	switch {
+	case g.nesting:
+		g.capture(fn(g.inner))
+	case len(g.frames) >= 2:
+		g.field(str)
+	}
+	g.sm.Next()
+	return list
+}
+
+func (g *Generator) field(value string) {
+	var sb strings.Builder
+	for index, f := range g.frames[1:] {
+		if index > 0 {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(f.name)
+	}
+	g.names = append(g.names, sb.String())
+	g.values = append(g.values, value)
+}
+
+func (g *Generator) record() []Appender {
+	var list []Appender
+	if g.index == nil {
+		list = g.header(g.names)
+	}
+
+	row := make([]string, len(g.columns))
+	for column := range row {
+		row[column] = g.csv.Null
+	}
+	filled := make([]bool, len(g.columns))
+	for index, name := range g.names {
+		column, found := g.index[name]
+		if !found {
+			return g.fail(fmt.Errorf("CSV field %q is not one of the columns", name))
+		}
+		if filled[column] {
+			return g.fail(fmt.Errorf("CSV record has more than one field named %q", name))
+		}
+		filled[column] = true
+		row[column] = g.values[index]
+	}
+	return append(list, g.row(row))
+}
+
+func (g *Generator) header(columns []string) []Appender {
+	g.columns = make([]string, 0, len(columns))
+	g.index = make(map[string]int, len(columns))
+	for _, name := range columns {
+		if _, found := g.index[name]; !found {
+			g.index[name] = len(g.columns)
+			g.columns = append(g.columns, name)
+		}
+	}
+
+	if g.csv.OmitHeader {
+		return nil
+	}
+	return []Appender{g.row(g.columns)}
+}
+
+func (g *Generator) row(fields []string) Appender {
+	return RecordAppender{Fields: fields, Separator: g.separator(), UseCRLF: g.csv.UseCRLF}
+}
+
+func (g *Generator) separator() rune {
+	if g.csv.Separator == 0 {
+		return ','
+	}
+	return g.csv.Separator
+}
+
+func (g *Generator) capture(list []Appender) {
+	for _, a := range list {
+		g.buf = a.Append(g.buf)
+	}
+}
+
+func (g *Generator) fail(err error) []Appender {
+	return []Appender{appenders.Error{Err: err}}
+}
+
+func (g *Generator) top() *frame {
+	return &g.frames[len(g.frames)-1]
+}
+
+var _ emitter.Generator = (*Generator)(nil)
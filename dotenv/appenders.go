@@ -0,0 +1,75 @@
+package dotenv
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chronos-tachyon/go-emitter/appenders"
+)
+
+type Appender = appenders.Appender
+
+// ValueAppender writes a value bare if it consists only of characters that
+// no .env parser treats specially, in single quotes if it can, and in double
+// quotes otherwise.
+type ValueAppender struct {
+	Value string
+}
+
+func (a ValueAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a ValueAppender) Append(out []byte) []byte {
+	bare, literal := true, !strings.Contains(a.Value, "'")
+	for i := 0; i < len(a.Value); i++ {
+		ch := a.Value[i]
+		bare = bare && isBare(ch)
+		literal = literal && ch >= 0x20 && ch != 0x7f
+	}
+
+	switch {
+	case bare:
+		return append(out, a.Value...)
+	case literal:
+		out = append(out, '\'')
+		out = append(out, a.Value...)
+		return append(out, '\'')
+	}
+
+	out = append(out, '"')
+	for i := 0; i < len(a.Value); i++ {
+		ch := a.Value[i]
+		switch ch {
+		case '\n':
+			out = append(out, '\\', 'n')
+		case '\r':
+			out = append(out, '\\', 'r')
+		case '\t':
+			out = append(out, '\\', 't')
+		case '"', '\\', '$', '`':
+			out = append(out, '\\', ch)
+		default:
+			out = append(out, ch)
+		}
+	}
+	return append(out, '"')
+}
+
+var (
+	_ fmt.Stringer = ValueAppender{}
+	_ Appender     = ValueAppender{}
+)
+
+func isBare(ch byte) bool {
+	switch {
+	case ch >= 'a' && ch <= 'z':
+	case ch >= 'A' && ch <= 'Z':
+	case ch >= '0' && ch <= '9':
+	case strings.IndexByte("_-+./:@,%", ch) >= 0:
+	case ch >= 0x80:
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,2 @@
+// Package dotenv implements .env files for Emitter.
+package dotenv
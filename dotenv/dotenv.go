@@ -0,0 +1,71 @@
+package dotenv
+
+import (
+	"strings"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/flatten"
+)
+
+// KeyFunc computes a variable name from the path leading to a value.
+type KeyFunc func(path []string) string
+
+// DotEnv generates .env files.  KeyFunc defaults to UpperSnake.  If Export
+// is set, each line starts with "export " so that the file can be sourced by
+// a POSIX shell.  If Strict is set, arrays are an error.
+type DotEnv struct {
+	KeyFunc KeyFunc
+	Export  bool
+	Strict  bool
+}
+
+func (dotenv DotEnv) NewGenerator() emitter.Generator {
+	return flatten.NewGenerator(formatter{dotenv: dotenv})
+}
+
+var _ emitter.GeneratorFactory = DotEnv{}
+
+// UpperSnake joins path with underscores and converts the result into a
+// conventional variable name, so that {"database": {"host": ...}} becomes
+// DATABASE_HOST.  Letters are upper-cased, other characters that are not
+// allowed in a name become underscores, and a leading digit gets an
+// underscore in front of it.
+func UpperSnake(path []string) string {
+	var sb strings.Builder
+	for index, part := range path {
+		if index > 0 {
+			sb.WriteByte('_')
+		}
+		for i := 0; i < len(part); i++ {
+			ch := part[i]
+			switch {
+			case ch >= 'a' && ch <= 'z':
+				sb.WriteByte(ch - 'a' + 'A')
+			case ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' || ch == '_':
+				sb.WriteByte(ch)
+			default:
+				sb.WriteByte('_')
+			}
+		}
+	}
+
+	name := sb.String()
+	if name == "" || name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+// IsName reports whether str is a valid variable name.
+func IsName(str string) bool {
+	if str == "" || str[0] >= '0' && str[0] <= '9' {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		ch := str[i]
+		if !(ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' || ch == '_') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+package dotenv
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+func TestDotEnv(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Input     Value
+		Factory   emitter.GeneratorFactory
+		Expect    string
+		ExpectErr error
+	}
+
+	config := values.Object{
+		{Key: "database", Value: values.Object{
+			{Key: "host", Value: values.String("db.example.com")},
+			{Key: "port", Value: values.Int(5432)},
+			{Key: "password", Value: values.String("it's a $ecret")},
+		}},
+		{Key: "servers", Value: values.Array{values.String("a"), values.String("b")}},
+		{Key: "log-level", Value: values.String("debug info")},
+	}
+
+	testData := [...]testCase{
+		{Name: "Config", Input: config, Factory: DotEnv{}, Expect: "DATABASE_HOST=db.example.com\nDATABASE_PORT=5432\nDATABASE_PASSWORD=\"it's a \\$ecret\"\nSERVERS_0=a\nSERVERS_1=b\nLOG_LEVEL='debug info'\n"},
+		{Name: "Config/Export", Input: values.Object{{Key: "debug", Value: values.Bool(true)}}, Factory: DotEnv{Export: true}, Expect: "export DEBUG=true\n"},
+		{
+			Name:    "Config/KeyFunc",
+			Input:   values.Object{{Key: "database", Value: values.Object{{Key: "host", Value: values.String("x")}}}},
+			Factory: DotEnv{KeyFunc: func(path []string) string { return "APP_" + UpperSnake(path) }},
+			Expect:  "APP_DATABASE_HOST=x\n",
+		},
+		{
+			Name: "Quoting",
+			Input: values.Object{
+				{Key: "empty", Value: values.String("")},
+				{Key: "null", Value: values.Null{}},
+				{Key: "url", Value: values.String("https://example.com/a?b=c")},
+				{Key: "multi", Value: values.String("line 1\nline \"2\"")},
+				{Key: "quote", Value: values.String("it's `here`")},
+				{Key: "unicode", Value: values.String("héllo")},
+			},
+			Factory: DotEnv{},
+			Expect:  "EMPTY=\nNULL=\nURL='https://example.com/a?b=c'\nMULTI=\"line 1\\nline \\\"2\\\"\"\nQUOTE=\"it's \\`here\\`\"\nUNICODE=héllo\n",
+		},
+		{Name: "Name/Digit", Input: values.Object{{Key: "1st", Value: values.Int(1)}}, Factory: DotEnv{}, Expect: "_1ST=1\n"},
+		{
+			Name:      "Error/Strict",
+			Input:     config,
+			Factory:   DotEnv{Strict: true},
+			ExpectErr: fmt.Errorf("dotenv variable %q would be an array, which strict mode does not allow", "SERVERS"),
+		},
+		{
+			Name:      "Error/Name",
+			Input:     values.Object{{Key: "a", Value: values.Int(1)}},
+			Factory:   DotEnv{KeyFunc: func(path []string) string { return strings.Join(path, ".") + "-" }},
+			ExpectErr: fmt.Errorf("dotenv variable name %q is not valid", "a-"),
+		},
+		{
+			Name:      "Error/TopLevel",
+			Input:     values.Array{values.Object(nil)},
+			Factory:   DotEnv{},
+			ExpectErr: fmt.Errorf("dotenv requires an object at the top level"),
+		},
+		{
+			Name: "Error/Duplicate",
+			Input: values.Object{
+				{Key: "database", Value: values.Object{{Key: "host", Value: values.String("a")}}},
+				{Key: "database_host", Value: values.String("b")},
+			},
+			Factory:   DotEnv{},
+			ExpectErr: fmt.Errorf("dotenv variable %q is set more than once", "DATABASE_HOST"),
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, row.Factory.NewGenerator())
+			row.Input.EmitTo(&e)
+			err := e.Close()
+
+			if !reflect.DeepEqual(err, row.ExpectErr) {
+				t.Errorf("wrong error:\n\texpect: %#v\n\tactual: %#v", row.ExpectErr, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+
+			if actual := buf.String(); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package dotenv
+
+import (
+	"fmt"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/flatten"
+)
+
+type formatter struct {
+	dotenv DotEnv
+}
+
+func (f formatter) Factory() emitter.GeneratorFactory {
+	return f.dotenv
+}
+
+func (f formatter) RootError() error {
+	return fmt.Errorf("dotenv requires an object at the top level")
+}
+
+func (f formatter) Null() string {
+	return ""
+}
+
+func (f formatter) StartRecord() []Appender {
+	return nil
+}
+
+func (f formatter) EndRecord() []Appender {
+	return nil
+}
+
+func (f formatter) Name(path []string) string {
+	fn := f.dotenv.KeyFunc
+	if fn == nil {
+		fn = UpperSnake
+	}
+	return fn(path)
+}
+
+func (f formatter) DuplicateError(name string) error {
+	return fmt.Errorf("dotenv variable %q is set more than once", name)
+}
+
+func (f formatter) Array(name string) []Appender {
+	if f.dotenv.Strict {
+		return fail(fmt.Errorf("dotenv variable %q would be an array, which strict mode does not allow", name))
+	}
+	return nil
+}
+
+func (f formatter) Field(name string, value string) []Appender {
+	if !IsName(name) {
+		return fail(fmt.Errorf("dotenv variable name %q is not valid", name))
+	}
+
+	var b appenders.Builder
+	if f.dotenv.Export {
+		b.AddString("export ")
+	}
+	b.AddString(name)
+	b.AddByte('=')
+	b.Add(ValueAppender{Value: value})
+	b.AddByte('\n')
+	return b.Build()
+}
+
+func fail(err error) []Appender {
+	return []Appender{appenders.Error{Err: err}}
+}
+
+var _ flatten.Formatter = formatter{}
@@ -0,0 +1,250 @@
+package emitter
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+// DuplicateKeyPolicy selects what Emitter does with a key that repeats an
+// earlier key in the same object; see Emitter.CheckDuplicateKeys.  Keys
+// written with StartKey and EndKey are checked only when the Generator is not
+// a KeyGenerator, because only then does Emitter convert them to strings; a
+// KeyGenerator receives them directly, and they are never tracked.
+type DuplicateKeyPolicy byte
+
+const (
+	AllowDuplicateKeys DuplicateKeyPolicy = iota
+	RejectDuplicateKeys
+	KeepFirstKey
+)
+
+const duplicateKeyPolicySize = 3
+
+var duplicateKeyPolicyGoNames = [duplicateKeyPolicySize]string{
+	"emitter.AllowDuplicateKeys",
+	"emitter.RejectDuplicateKeys",
+	"emitter.KeepFirstKey",
+}
+
+var duplicateKeyPolicyNames = [duplicateKeyPolicySize]string{
+	"allow",
+	"reject",
+	"keepFirst",
+}
+
+func (p DuplicateKeyPolicy) IsValid() bool {
+	return p < duplicateKeyPolicySize
+}
+
+func (p DuplicateKeyPolicy) GoString() string {
+	if p.IsValid() {
+		return duplicateKeyPolicyGoNames[p]
+	}
+	return fmt.Sprintf("emitter.DuplicateKeyPolicy(%d)", uint(p))
+}
+
+func (p DuplicateKeyPolicy) String() string {
+	if p.IsValid() {
+		return duplicateKeyPolicyNames[p]
+	}
+	return fmt.Sprintf("%%!ERR[invalid emitter.DuplicateKeyPolicy %d]", uint(p))
+}
+
+var (
+	_ fmt.GoStringer = DuplicateKeyPolicy(0)
+	_ fmt.Stringer   = DuplicateKeyPolicy(0)
+)
+
+// DefaultMaxTrackedKeys is the per-object limit used when CheckDuplicateKeys
+// is given a limit of 0.
+const DefaultMaxTrackedKeys = 4096
+
+type keyFrame struct {
+	seen     map[string]struct{}
+	isObject bool
+}
+
+type keyTracker struct {
+	policy DuplicateKeyPolicy
+	limit  uint
+	frames []keyFrame
+	depth  uint
+}
+
+func (kt *keyTracker) enabled() bool {
+	return kt.policy != AllowDuplicateKeys
+}
+
+func (kt *keyTracker) push(isObject bool) {
+	if kt.depth >= uint(len(kt.frames)) {
+		kt.frames = append(kt.frames, keyFrame{})
+	}
+	frame := &kt.frames[kt.depth]
+	frame.isObject = isObject
+	if frame.seen != nil {
+		clear(frame.seen)
+	}
+	kt.depth++
+}
+
+func (kt *keyTracker) pop() {
+	if kt.depth > 0 {
+		kt.depth--
+	}
+}
+
+func (kt *keyTracker) isDuplicate(key string) bool {
+	if kt.depth <= 0 {
+		return false
+	}
+
+	frame := &kt.frames[kt.depth-1]
+	if !frame.isObject {
+		return false
+	}
+	if _, found := frame.seen[key]; found {
+		return true
+	}
+	if frame.seen == nil {
+		frame.seen = make(map[string]struct{})
+	}
+	if uint(len(frame.seen)) < kt.limit {
+		frame.seen[key] = struct{}{}
+	}
+	return false
+}
+
+// valueSkipper is a Generator that silently consumes exactly one value, which
+// may be a container.  Emitter uses it to drop the value following a
+// duplicate key.
+type valueSkipper struct {
+	parent Generator
+	sm     states.Machine
+}
+
+func (vs *valueSkipper) Reset() {
+	vs.sm.Reset()
+}
+
+func (vs *valueSkipper) Factory() GeneratorFactory {
+	return vs.parent.Factory()
+}
+
+func (vs *valueSkipper) Begin() []Appender {
+	panic(vs.sm.State.Unexpected())
+}
+
+func (vs *valueSkipper) End() []Appender {
+	panic(vs.sm.State.Unexpected())
+}
+
+func (vs *valueSkipper) StartObject() []Appender {
+	vs.sm.ExpectValue()
+	vs.sm.Push(states.ObjectFirstKey)
+	return nil
+}
+
+func (vs *valueSkipper) EndObject() []Appender {
+	vs.sm.ExpectKey()
+	vs.sm.Pop()
+	vs.sm.Next()
+	return nil
+}
+
+func (vs *valueSkipper) StartArray() []Appender {
+	vs.sm.ExpectValue()
+	vs.sm.Push(states.ArrayFirstValue)
+	return nil
+}
+
+func (vs *valueSkipper) EndArray() []Appender {
+	vs.sm.ExpectArray()
+	vs.sm.Pop()
+	vs.sm.Next()
+	return nil
+}
+
+func (vs *valueSkipper) Key(key string) []Appender {
+	vs.sm.ExpectKey()
+	vs.sm.Next()
+	return nil
+}
+
+func (vs *valueSkipper) NullValue() []Appender {
+	return vs.value()
+}
+
+func (vs *valueSkipper) BoolValue(value bool) []Appender {
+	return vs.value()
+}
+
+func (vs *valueSkipper) IntValue(value int64) []Appender {
+	return vs.value()
+}
+
+func (vs *valueSkipper) UintValue(value uint64) []Appender {
+	return vs.value()
+}
+
+func (vs *valueSkipper) BigIntValue(value *big.Int) []Appender {
+	return vs.value()
+}
+
+func (vs *valueSkipper) NaNValue() []Appender {
+	return vs.value()
+}
+
+func (vs *valueSkipper) InfValue(isNeg bool) []Appender {
+	return vs.value()
+}
+
+func (vs *valueSkipper) FloatValue(value float64) []Appender {
+	return vs.value()
+}
+
+func (vs *valueSkipper) BigFloatValue(value *big.Float) []Appender {
+	return vs.value()
+}
+
+func (vs *valueSkipper) BigRatValue(value *big.Rat) []Appender {
+	return vs.value()
+}
+
+func (vs *valueSkipper) ComplexValue(value complex128) []Appender {
+	return vs.value()
+}
+
+func (vs *valueSkipper) StringValue(value string) []Appender {
+	return vs.value()
+}
+
+func (vs *valueSkipper) BytesValue(value []byte) []Appender {
+	return vs.value()
+}
+
+func (vs *valueSkipper) ByteValue(value byte) []Appender {
+	return vs.value()
+}
+
+func (vs *valueSkipper) RuneValue(value rune) []Appender {
+	return vs.value()
+}
+
+func (vs *valueSkipper) TimeValue(value time.Time) []Appender {
+	return vs.value()
+}
+
+func (vs *valueSkipper) value() []Appender {
+	vs.sm.ExpectValue()
+	vs.sm.Next()
+	return nil
+}
+
+func (vs *valueSkipper) done() bool {
+	return vs.sm.State == states.End
+}
+
+var _ Generator = (*valueSkipper)(nil)
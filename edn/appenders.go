@@ -0,0 +1,112 @@
+package edn
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/chronos-tachyon/go-emitter/appenders"
+)
+
+type Appender = appenders.Appender
+
+// IsKeyword reports whether ":"+str is a valid EDN keyword.
+func IsKeyword(str string) bool {
+	if str == "" {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		ch := str[i]
+		switch {
+		case ch >= 'a' && ch <= 'z':
+		case ch >= 'A' && ch <= 'Z':
+		case strings.IndexByte("*+!-_?<>=.", ch) >= 0:
+		case (ch >= '0' && ch <= '9') || ch == '#' || ch == '\'':
+			if i == 0 {
+				return false
+			}
+			if i == 1 && ch >= '0' && ch <= '9' && strings.IndexByte("+-.", str[0]) >= 0 {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// IsUUID reports whether str is a UUID in canonical 8-4-4-4-12 form.
+func IsUUID(str string) bool {
+	if len(str) != 36 {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		ch := str[i]
+		switch i {
+		case 8, 13, 18, 23:
+			if ch != '-' {
+				return false
+			}
+		default:
+			if !(ch >= '0' && ch <= '9') && !(ch >= 'a' && ch <= 'f') && !(ch >= 'A' && ch <= 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+type CharAppender rune
+
+func (a CharAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a CharAppender) Append(out []byte) []byte {
+	ch := rune(a)
+	if name, found := charNames[ch]; found {
+		return append(out, name...)
+	}
+	if unicode.IsGraphic(ch) && ch < 0x10000 {
+		out = append(out, '\\')
+		return append(out, string(ch)...)
+	}
+	return fmt.Appendf(out, "\\u%04x", ch)
+}
+
+var (
+	_ fmt.Stringer = CharAppender(0)
+	_ Appender     = CharAppender(0)
+)
+
+var charNames = map[rune]string{
+	'\b': `\backspace`,
+	'\t': `\tab`,
+	'\n': `\newline`,
+	'\f': `\formfeed`,
+	'\r': `\return`,
+	' ':  `\space`,
+}
+
+// FloatAppender writes a finite float so that it reads back as a float, not
+// an integer.
+type FloatAppender float64
+
+func (a FloatAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a FloatAppender) Append(out []byte) []byte {
+	start := len(out)
+	out = strconv.AppendFloat(out, float64(a), 'g', -1, 64)
+	if !strings.ContainsAny(string(out[start:]), ".e") {
+		out = append(out, '.', '0')
+	}
+	return out
+}
+
+var (
+	_ fmt.Stringer = FloatAppender(0)
+	_ Appender     = FloatAppender(0)
+)
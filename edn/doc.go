@@ -0,0 +1,2 @@
+// Package edn implements Extensible Data Notation for Emitter.
+package edn
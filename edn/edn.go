@@ -0,0 +1,25 @@
+package edn
+
+import (
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/json"
+)
+
+// EDN generates Extensible Data Notation.  If KeywordKeys is set, string
+// keys that are valid keyword names are written as keywords.  If UUIDStrings
+// is set, strings holding a canonical UUID are written as #uuid literals.
+type EDN struct {
+	Format         json.Format
+	IndentSize     uint
+	IndentWithTabs bool
+	KeywordKeys    bool
+	UUIDStrings    bool
+}
+
+func (edn EDN) NewGenerator() emitter.Generator {
+	g := &Generator{edn: edn}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = EDN{}
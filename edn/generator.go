@@ -0,0 +1,278 @@
+package edn
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/json"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+type Generator struct {
+	edn EDN
+	sm  states.Machine
+}
+
+func (g *Generator) Reset() {
+	g.sm.Reset()
+}
+
+func (g *Generator) Factory() emitter.GeneratorFactory {
+	return g.edn
+}
+
+func (g *Generator) Begin() []Appender {
+	g.sm.ExpectRoot()
+	return nil
+}
+
+func (g *Generator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+
+	var b appenders.Builder
+	g.edn.Format.LineFeed(&b)
+	return b.Build()
+}
+
+func (g *Generator) StartObject() []Appender {
+	return g.startContainer('{', states.ObjectFirstKey)
+}
+
+func (g *Generator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	return g.endContainer('}', states.ObjectNextKey)
+}
+
+func (g *Generator) StartArray() []Appender {
+	return g.startContainer('[', states.ArrayFirstValue)
+}
+
+func (g *Generator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	return g.endContainer(']', states.ArrayNextValue)
+}
+
+func (g *Generator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+
+	var b appenders.Builder
+	g.keySeparator(&b)
+	if g.edn.KeywordKeys && IsKeyword(key) {
+		b.AddByte(':')
+		b.AddString(key)
+	} else {
+		b.Add(json.StringAppender{Value: key})
+	}
+	b.AddByte(' ')
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) StartKey() []Appender {
+	var b appenders.Builder
+	g.keySeparator(&b)
+	g.sm.StartKey()
+	return b.Build()
+}
+
+func (g *Generator) EndKey() []Appender {
+	g.sm.EndKey()
+	return []Appender{appenders.LiteralString(" ")}
+}
+
+func (g *Generator) NullValue() []Appender {
+	return g.literal(`nil`)
+}
+
+func (g *Generator) BoolValue(value bool) []Appender {
+	if value {
+		return g.literal(`true`)
+	}
+	return g.literal(`false`)
+}
+
+func (g *Generator) IntValue(value int64) []Appender {
+	return g.value(appenders.IntText(value))
+}
+
+func (g *Generator) UintValue(value uint64) []Appender {
+	if value > math.MaxInt64 {
+		return g.BigIntValue(new(big.Int).SetUint64(value))
+	}
+	return g.value(appenders.UintText(value))
+}
+
+func (g *Generator) BigIntValue(value *big.Int) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if value.IsInt64() {
+		return g.IntValue(value.Int64())
+	}
+	return g.literal(value.String() + "N")
+}
+
+func (g *Generator) NaNValue() []Appender {
+	return g.literal(`##NaN`)
+}
+
+func (g *Generator) InfValue(isNeg bool) []Appender {
+	if isNeg {
+		return g.literal(`##-Inf`)
+	}
+	return g.literal(`##Inf`)
+}
+
+func (g *Generator) FloatValue(value float64) []Appender {
+	switch {
+	case math.IsNaN(value):
+		return g.NaNValue()
+	case math.IsInf(value, 0):
+		return g.InfValue(value < 0)
+	default:
+		return g.value(FloatAppender(value))
+	}
+}
+
+func (g *Generator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if value.IsInf() {
+		return g.InfValue(value.Signbit())
+	}
+	str := value.Text('g', -1)
+	if !strings.ContainsAny(str, ".e") {
+		str += ".0"
+	}
+	return g.literal(str + "M")
+}
+
+func (g *Generator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if value.IsInt() {
+		return g.BigIntValue(value.Num())
+	}
+	return g.literal(value.Num().String() + "/" + value.Denom().String())
+}
+
+func (g *Generator) ComplexValue(value complex128) []Appender {
+	var list []Appender
+	list = append(list, g.StartObject()...)
+	list = append(list, g.Key("re")...)
+	list = append(list, g.FloatValue(real(value))...)
+	list = append(list, g.Key("im")...)
+	list = append(list, g.FloatValue(imag(value))...)
+	list = append(list, g.EndObject()...)
+	return list
+}
+
+func (g *Generator) StringValue(value string) []Appender {
+	if g.edn.UUIDStrings && IsUUID(value) {
+		return g.literal(`#uuid "` + strings.ToLower(value) + `"`)
+	}
+	return g.value(json.StringAppender{Value: value})
+}
+
+func (g *Generator) BytesValue(value []byte) []Appender {
+	return g.value(json.BytesAppender{Value: value})
+}
+
+func (g *Generator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *Generator) RuneValue(value rune) []Appender {
+	if !utf8.ValidRune(value) || value >= 0x10000 {
+		// Characters are UTF-16 code units in Clojure.
+		return g.StringValue(string(value))
+	}
+	return g.value(CharAppender(value))
+}
+
+func (g *Generator) TimeValue(value time.Time) []Appender {
+	return g.literal(`#inst "` + value.Format(time.RFC3339Nano) + `"`)
+}
+
+func (g *Generator) startContainer(open byte, next states.State) []Appender {
+	g.sm.ExpectValue()
+
+	var b appenders.Builder
+	g.separator(&b)
+	g.sm.Push(next)
+	b.AddByte(open)
+	return b.Build()
+}
+
+func (g *Generator) endContainer(close byte, nonEmpty states.State) []Appender {
+	needIndent := g.sm.State.In(nonEmpty)
+	g.sm.Pop()
+
+	var b appenders.Builder
+	if needIndent {
+		g.indent(&b)
+	}
+	b.AddByte(close)
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) keySeparator(b *appenders.Builder) {
+	if g.sm.State.In(states.ObjectFirstKey) {
+		g.indent(b)
+	}
+	if g.sm.State.In(states.ObjectNextKey) {
+		b.AddByte(',')
+		g.indentOrSpace(b)
+	}
+}
+
+// separator writes the whitespace between vector elements, which (unlike
+// JSON) cannot be omitted even in compact output.
+func (g *Generator) separator(b *appenders.Builder) {
+	if g.sm.State.In(states.ArrayFirstValue) {
+		g.indent(b)
+	}
+	if g.sm.State.In(states.ArrayNextValue) {
+		if g.edn.Format == json.Compact {
+			b.AddByte(' ')
+		} else {
+			g.indentOrSpace(b)
+		}
+	}
+}
+
+func (g *Generator) value(a Appender) []Appender {
+	g.sm.ExpectValue()
+
+	var b appenders.Builder
+	g.separator(&b)
+	b.Add(a)
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) literal(str string) []Appender {
+	return g.value(appenders.LiteralString(str))
+}
+
+func (g *Generator) indent(b *appenders.Builder) {
+	g.edn.Format.Indent(b, g.edn.IndentWithTabs, g.edn.IndentSize, g.sm.Depth())
+}
+
+func (g *Generator) indentOrSpace(b *appenders.Builder) {
+	g.edn.Format.IndentOrSpace(b, g.edn.IndentWithTabs, g.edn.IndentSize, g.sm.Depth())
+}
+
+var (
+	_ emitter.Generator    = (*Generator)(nil)
+	_ emitter.KeyGenerator = (*Generator)(nil)
+)
@@ -6,6 +6,9 @@ import (
 	"math"
 	"math/big"
 	"reflect"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter/appenders"
 )
 
 const (
@@ -16,6 +19,10 @@ const (
 type Emitter struct {
 	w       io.Writer
 	g       Generator
+	cur     Generator
+	ks      keyStringer
+	vs      valueSkipper
+	kt      keyTracker
 	out     []byte
 	err     error
 	n       int64
@@ -38,7 +45,7 @@ func (e *Emitter) Reset(w io.Writer, g Generator) {
 	}
 
 	g.Reset()
-	*e = Emitter{w: w, g: g}
+	*e = Emitter{w: w, g: g, cur: g}
 	e.out = e.scratch[:0]
 	e.apply(g.Begin())
 }
@@ -55,24 +62,114 @@ func (e *Emitter) BytesWritten() int64 {
 	return e.n
 }
 
+// CheckDuplicateKeys enables detection of string keys that appear more than
+// once in the same object.  At most maxKeys distinct keys are remembered per
+// object (DefaultMaxTrackedKeys if maxKeys is 0); keys beyond that limit are
+// not checked.  The setting lasts until the next call to Reset.
+func (e *Emitter) CheckDuplicateKeys(policy DuplicateKeyPolicy, maxKeys uint) {
+	if !policy.IsValid() {
+		panic(fmt.Errorf("invalid %#v", policy))
+	}
+	if maxKeys <= 0 {
+		maxKeys = DefaultMaxTrackedKeys
+	}
+	e.kt.policy = policy
+	e.kt.limit = maxKeys
+}
+
 func (e *Emitter) StartObject() {
-	e.apply(e.g.StartObject())
+	if e.tracking() {
+		e.kt.push(true)
+	}
+	e.apply(e.cur.StartObject())
 }
 
 func (e *Emitter) EndObject() {
-	e.apply(e.g.EndObject())
+	if e.tracking() {
+		e.kt.pop()
+	}
+	e.apply(e.cur.EndObject())
 }
 
 func (e *Emitter) StartArray() {
-	e.apply(e.g.StartArray())
+	if e.tracking() {
+		e.kt.push(false)
+	}
+	e.apply(e.cur.StartArray())
 }
 
 func (e *Emitter) EndArray() {
-	e.apply(e.g.EndArray())
+	if e.tracking() {
+		e.kt.pop()
+	}
+	e.apply(e.cur.EndArray())
 }
 
 func (e *Emitter) EmitKey(key string) {
-	e.apply(e.g.Key(key))
+	if e.tracking() && e.kt.isDuplicate(key) {
+		switch e.kt.policy {
+		case RejectDuplicateKeys:
+			if e.err == nil {
+				e.err = fmt.Errorf("duplicate key %q", key)
+			}
+		case KeepFirstKey:
+			e.vs.Reset()
+			e.vs.parent = e.cur
+			e.cur = &e.vs
+			return
+		}
+	}
+	e.apply(e.cur.Key(key))
+}
+
+func (e *Emitter) StartKey() {
+	if e.cur == &e.ks {
+		panic(fmt.Errorf("nested keys are not supported"))
+	}
+
+	if kg, ok := e.cur.(KeyGenerator); ok {
+		e.apply(kg.StartKey())
+		return
+	}
+
+	e.ks.Reset()
+	e.ks.parent = e.cur
+	e.cur = &e.ks
+}
+
+func (e *Emitter) EndKey() {
+	if e.cur == &e.ks {
+		key := e.ks.finish()
+		e.cur = e.ks.parent
+		e.ks.parent = nil
+		e.EmitKey(key)
+		return
+	}
+
+	kg, ok := e.cur.(KeyGenerator)
+	if !ok {
+		panic(fmt.Errorf("EndKey without StartKey"))
+	}
+	e.apply(kg.EndKey())
+}
+
+func (e *Emitter) EmitKeyValue(key any) {
+	if str, ok := key.(string); ok {
+		e.EmitKey(str)
+		return
+	}
+
+	e.StartKey()
+	e.Emit(key)
+	e.EndKey()
+}
+
+// Annotate attaches annotations to the next value, if the Generator supports
+// them; see AnnotationGenerator.
+func (e *Emitter) Annotate(annotations ...string) {
+	if ag, ok := e.cur.(AnnotationGenerator); ok && len(annotations) > 0 {
+		e.apply(ag.Annotate(annotations))
+	}
 }
 
 func (e *Emitter) EmitValue(value Value) {
@@ -80,11 +177,11 @@ func (e *Emitter) EmitValue(value Value) {
 }
 
 func (e *Emitter) EmitNull() {
-	e.apply(e.g.NullValue())
+	e.apply(e.cur.NullValue())
 }
 
 func (e *Emitter) EmitBool(value bool) {
-	e.apply(e.g.BoolValue(value))
+	e.apply(e.cur.BoolValue(value))
 }
 
 func (e *Emitter) EmitInt(value int) {
@@ -104,7 +201,7 @@ func (e *Emitter) EmitInt32(value int32) {
 }
 
 func (e *Emitter) EmitInt64(value int64) {
-	e.apply(e.g.IntValue(value))
+	e.apply(e.cur.IntValue(value))
 }
 
 func (e *Emitter) EmitUint(value uint) {
@@ -124,11 +221,11 @@ func (e *Emitter) EmitUint32(value uint32) {
 }
 
 func (e *Emitter) EmitUint64(value uint64) {
-	e.apply(e.g.UintValue(value))
+	e.apply(e.cur.UintValue(value))
 }
 
 func (e *Emitter) EmitBigInt(value *big.Int) {
-	e.apply(e.g.BigIntValue(value))
+	e.apply(e.cur.BigIntValue(value))
 }
 
 func (e *Emitter) EmitFloat32(value float32) {
@@ -138,32 +235,56 @@ func (e *Emitter) EmitFloat32(value float32) {
 func (e *Emitter) EmitFloat64(value float64) {
 	switch {
 	case math.IsNaN(value):
-		e.apply(e.g.NaNValue())
+		e.apply(e.cur.NaNValue())
 	case math.IsInf(value, 0):
-		e.apply(e.g.InfValue(value < 0))
+		e.apply(e.cur.InfValue(value < 0))
 	default:
-		e.apply(e.g.FloatValue(value))
+		e.apply(e.cur.FloatValue(value))
 	}
 }
 
 func (e *Emitter) EmitBigFloat(value *big.Float) {
-	e.apply(e.g.BigFloatValue(value))
+	e.apply(e.cur.BigFloatValue(value))
+}
+
+func (e *Emitter) EmitBigRat(value *big.Rat) {
+	e.apply(e.cur.BigRatValue(value))
+}
+
+func (e *Emitter) EmitComplex64(value complex64) {
+	e.EmitComplex128(complex128(value))
+}
+
+func (e *Emitter) EmitComplex128(value complex128) {
+	e.apply(e.cur.ComplexValue(value))
 }
 
 func (e *Emitter) EmitString(value string) {
-	e.apply(e.g.StringValue(value))
+	e.apply(e.cur.StringValue(value))
 }
 
 func (e *Emitter) EmitBytes(value []byte) {
-	e.apply(e.g.BytesValue(value))
+	e.apply(e.cur.BytesValue(value))
 }
 
 func (e *Emitter) EmitByte(value byte) {
-	e.apply(e.g.ByteValue(value))
+	e.apply(e.cur.ByteValue(value))
 }
 
 func (e *Emitter) EmitRune(value rune) {
-	e.apply(e.g.RuneValue(value))
+	e.apply(e.cur.RuneValue(value))
+}
+
+func (e *Emitter) EmitTime(value time.Time) {
+	e.apply(e.cur.TimeValue(value))
+}
+
+func (e *Emitter) EmitTypedArray(value TypedArray) {
+	if tg, ok := e.cur.(TypedArrayGenerator); ok {
+		e.apply(tg.TypedArrayValue(value))
+		return
+	}
+	value.emitArray(e)
 }
 
 func (e *Emitter) Emit(value any) {
@@ -202,10 +323,21 @@ func (e *Emitter) Emit(value any) {
 		e.EmitFloat32(x)
 	case *big.Float:
 		e.EmitBigFloat(x)
+	case *big.Rat:
+		e.EmitBigRat(x)
+	case complex128:
+		e.EmitComplex128(x)
+	case complex64:
+		e.EmitComplex64(x)
 	case string:
 		e.EmitString(x)
 	case []byte:
 		e.EmitBytes(x)
+	case time.Time:
+		e.EmitTime(x)
+	case []int8, []int16, []int32, []int64, []uint16, []uint32, []uint64, []float32, []float64:
+		typed, _ := NewTypedArray(x)
+		e.EmitTypedArray(typed)
 	case reflect.Value:
 		e.EmitReflected(x)
 	default:
@@ -217,6 +349,14 @@ func (e *Emitter) EmitReflected(value reflect.Value) {
 	panic(fmt.Errorf("EmitReflected not implemented for %v", value.Type()))
 }
 
+func (e *Emitter) NextDocument() {
+	sg, ok := e.cur.(StreamGenerator)
+	if !ok {
+		panic(fmt.Errorf("%T does not support multiple documents", e.cur))
+	}
+	e.apply(sg.NextDocument())
+}
+
 func (e *Emitter) Flush() error {
 	if e.err == nil && len(e.out) > 0 {
 		e.flush()
@@ -225,19 +365,32 @@ func (e *Emitter) Flush() error {
 }
 
 func (e *Emitter) Close() error {
-	e.apply(e.g.End())
+	e.apply(e.cur.End())
 	if e.err == nil {
 		e.flush()
 	}
 	return e.err
 }
 
+func (e *Emitter) tracking() bool {
+	return e.kt.enabled() && e.cur != &e.vs
+}
+
 func (e *Emitter) apply(list []Appender) {
+	if e.cur == &e.vs && e.vs.done() {
+		e.cur = e.vs.parent
+		e.vs.parent = nil
+	}
+
 	if e.err != nil {
 		return
 	}
 
 	for _, a := range list {
+		if x, ok := a.(appenders.Error); ok {
+			e.err = x.Err
+			return
+		}
 		e.out = a.Append(e.out)
 	}
 
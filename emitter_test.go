@@ -0,0 +1,172 @@
+package emitter_test
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/json"
+	"github.com/chronos-tachyon/go-emitter/lua"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+func TestDuplicateKeys(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Policy    emitter.DuplicateKeyPolicy
+		MaxKeys   uint
+		Expect    string
+		ExpectErr error
+	}
+
+	input := values.Object{
+		{Key: "a", Value: values.Int(1)},
+		{Key: "b", Value: values.Object{
+			{Key: "a", Value: values.Int(2)},
+			{Key: "a", Value: values.Int(3)},
+		}},
+		{Key: "c", Value: values.Int(4)},
+		{Key: "a", Value: values.Array{values.Int(5), values.Null{}}},
+		{Key: "d", Value: values.Int(6)},
+	}
+
+	testData := [...]testCase{
+		{
+			Name:   "Allow",
+			Policy: emitter.AllowDuplicateKeys,
+			Expect: `{"a":1,"b":{"a":2,"a":3},"c":4,"a":[5,null],"d":6}`,
+		},
+		{
+			Name:      "Reject",
+			Policy:    emitter.RejectDuplicateKeys,
+			ExpectErr: fmt.Errorf("duplicate key %q", "a"),
+		},
+		{
+			Name:   "KeepFirst",
+			Policy: emitter.KeepFirstKey,
+			Expect: `{"a":1,"b":{"a":2},"c":4,"d":6}`,
+		},
+		{
+			Name:    "KeepFirst/Bounded",
+			Policy:  emitter.KeepFirstKey,
+			MaxKeys: 1,
+			Expect:  `{"a":1,"b":{"a":2},"c":4,"d":6}`,
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, json.JSON{}.NewGenerator())
+			e.CheckDuplicateKeys(row.Policy, row.MaxKeys)
+			input.EmitTo(&e)
+			err := e.Close()
+
+			if !reflect.DeepEqual(err, row.ExpectErr) {
+				t.Errorf("wrong error:\n\texpect: %#v\n\tactual: %#v", row.ExpectErr, err)
+				return
+			}
+
+			if actual := buf.String(); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestDuplicateMapKeys(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Factory   emitter.GeneratorFactory
+		Expect    string
+		ExpectErr error
+	}
+
+	input := values.Map{
+		{Key: values.Int(1), Value: values.String("a")},
+		{Key: values.Int(1), Value: values.String("b")},
+	}
+
+	testData := [...]testCase{
+		{
+			Name:      "Converted",
+			Factory:   json.JSON{},
+			ExpectErr: fmt.Errorf("duplicate key %q", "1"),
+		},
+		{
+			Name:    "KeyGenerator",
+			Factory: lua.Lua{},
+			Expect:  `{[1]="a",[1]="b"}`,
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, row.Factory.NewGenerator())
+			e.CheckDuplicateKeys(emitter.RejectDuplicateKeys, 0)
+			input.EmitTo(&e)
+			err := e.Close()
+
+			if !reflect.DeepEqual(err, row.ExpectErr) {
+				t.Errorf("wrong error:\n\texpect: %#v\n\tactual: %#v", row.ExpectErr, err)
+				return
+			}
+
+			if actual := buf.String(); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestTypedArray(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Input  any
+		Expect string
+	}
+
+	testData := [...]testCase{
+		{Name: "Int8", Input: []int8{-1, 2}, Expect: `[-1,2]`},
+		{Name: "Uint16", Input: []uint16{65535}, Expect: `[65535]`},
+		{Name: "Uint64", Input: []uint64{1 << 63}, Expect: `[9223372036854775808]`},
+		{Name: "Float32", Input: []float32{1.5, float32(math.Inf(1))}, Expect: `[1.5,"+Inf"]`},
+		{Name: "Empty", Input: []float64(nil), Expect: `[]`},
+		{Name: "Bytes", Input: []byte{1, 2}, Expect: `"AQI="`},
+		{Name: "Nested", Input: values.Object{{Key: "v", Value: typed([]int32{7})}}, Expect: `{"v":[7]}`},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, json.JSON{}.NewGenerator())
+			e.Emit(row.Input)
+			if err := e.Close(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if actual := buf.String(); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func typed(slice any) emitter.Value {
+	value, ok := emitter.NewTypedArray(slice)
+	if !ok {
+		panic(slice)
+	}
+	return value
+}
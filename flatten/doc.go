@@ -0,0 +1,2 @@
+// Package flatten contains a Generator for formats that write each scalar as a key path and value.
+package flatten
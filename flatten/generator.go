@@ -0,0 +1,312 @@
+package flatten
+
+import (
+	"encoding/base64"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+type Appender = appenders.Appender
+
+// Formatter writes the output of a Generator.  Each path passed to a
+// Formatter is only valid for the duration of the call.
+type Formatter interface {
+	// Factory returns the GeneratorFactory that the Generator reports.
+	Factory() emitter.GeneratorFactory
+
+	// RootError returns the error for a top-level value that is not an
+	// object.
+	RootError() error
+
+	// Null returns the text written for a null value.
+	Null() string
+
+	// StartRecord and EndRecord are called around each top-level value.
+	StartRecord() []Appender
+	EndRecord() []Appender
+
+	// Name returns the name under which the value at path is written.
+	Name(path []string) string
+
+	// DuplicateError returns the error for a record in which two values
+	// have the same name.
+	DuplicateError(name string) error
+
+	// Array is called when an array starts below the top level.
+	Array(name string) []Appender
+
+	// Field writes a scalar value with the given name.
+	Field(name string, value string) []Appender
+}
+
+type frame struct {
+	isArray bool
+	index   uint64
+	name    string
+}
+
+// Generator flattens nested objects and arrays into the path of keys and
+// array indices that leads to each scalar, and passes the result to a
+// Formatter.  Within each record, no two values may have the same name.
+type Generator struct {
+	f      Formatter
+	sm     states.Machine
+	frames []frame
+	path   []string
+	seen   map[string]struct{}
+}
+
+// NewGenerator returns a Generator that writes its output using f.
+func NewGenerator(f Formatter) *Generator {
+	g := &Generator{f: f}
+	g.Reset()
+	return g
+}
+
+// NewStreamGenerator is like NewGenerator, but the Generator it returns
+// accepts any number of top-level values.
+func NewStreamGenerator(f Formatter) emitter.StreamGenerator {
+	return streamGenerator{NewGenerator(f)}
+}
+
+func (g *Generator) Reset() {
+	g.sm.Reset()
+	g.frames = g.frames[:0]
+	g.path = g.path[:0]
+	clear(g.seen)
+}
+
+func (g *Generator) Factory() emitter.GeneratorFactory {
+	return g.f.Factory()
+}
+
+func (g *Generator) Begin() []Appender {
+	g.sm.ExpectRoot()
+	return nil
+}
+
+func (g *Generator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+	return nil
+}
+
+func (g *Generator) StartObject() []Appender {
+	return g.startContainer(false, states.ObjectFirstKey)
+}
+
+func (g *Generator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	return g.endContainer()
+}
+
+func (g *Generator) StartArray() []Appender {
+	return g.startContainer(true, states.ArrayFirstValue)
+}
+
+func (g *Generator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	return g.endContainer()
+}
+
+func (g *Generator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+	g.top().name = key
+	g.sm.Next()
+	return nil
+}
+
+func (g *Generator) NullValue() []Appender {
+	return g.scalar(g.f.Null())
+}
+
+func (g *Generator) BoolValue(value bool) []Appender {
+	return g.scalar(strconv.FormatBool(value))
+}
+
+func (g *Generator) IntValue(value int64) []Appender {
+	return g.scalar(strconv.FormatInt(value, 10))
+}
+
+func (g *Generator) UintValue(value uint64) []Appender {
+	return g.scalar(strconv.FormatUint(value, 10))
+}
+
+func (g *Generator) BigIntValue(value *big.Int) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	return g.scalar(value.String())
+}
+
+func (g *Generator) NaNValue() []Appender {
+	return g.scalar("NaN")
+}
+
+func (g *Generator) InfValue(isNeg bool) []Appender {
+	if isNeg {
+		return g.scalar("-Inf")
+	}
+	return g.scalar("+Inf")
+}
+
+func (g *Generator) FloatValue(value float64) []Appender {
+	switch {
+	case math.IsNaN(value):
+		return g.NaNValue()
+	case math.IsInf(value, 0):
+		return g.InfValue(value < 0)
+	default:
+		return g.scalar(strconv.FormatFloat(value, 'g', -1, 64))
+	}
+}
+
+func (g *Generator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if value.IsInf() {
+		return g.InfValue(value.Signbit())
+	}
+	return g.scalar(value.Text('g', -1))
+}
+
+func (g *Generator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if _, ok := appenders.TerminatingDigits(value); ok {
+		return g.scalar(string(appenders.BigRatDecimalText{Pointer: value}.Append(nil)))
+	}
+	return g.scalar(value.String())
+}
+
+func (g *Generator) ComplexValue(value complex128) []Appender {
+	var list []Appender
+	list = append(list, g.StartObject()...)
+	list = append(list, g.Key("re")...)
+	list = append(list, g.FloatValue(real(value))...)
+	list = append(list, g.Key("im")...)
+	list = append(list, g.FloatValue(imag(value))...)
+	list = append(list, g.EndObject()...)
+	return list
+}
+
+func (g *Generator) StringValue(value string) []Appender {
+	return g.scalar(value)
+}
+
+func (g *Generator) BytesValue(value []byte) []Appender {
+	return g.scalar(base64.StdEncoding.EncodeToString(value))
+}
+
+func (g *Generator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *Generator) RuneValue(value rune) []Appender {
+	return g.StringValue(string(value))
+}
+
+func (g *Generator) TimeValue(value time.Time) []Appender {
+	return g.scalar(value.Format(time.RFC3339Nano))
+}
+
+func (g *Generator) startContainer(isArray bool, next states.State) []Appender {
+	g.beginValue()
+
+	var list []Appender
+	switch {
+	case len(g.frames) == 0:
+		if isArray {
+			list = g.fail(g.f.RootError())
+		}
+		clear(g.seen)
+		list = append(list, g.f.StartRecord()...)
+	case isArray:
+		list = g.f.Array(g.f.Name(g.names()))
+	}
+	g.sm.Push(next)
+	g.frames = append(g.frames, frame{isArray: isArray})
+	return list
+}
+
+func (g *Generator) endContainer() []Appender {
+	n := len(g.frames) - 1
+	g.frames = g.frames[:n]
+	g.sm.Pop()
+	g.sm.Next()
+
+	if n == 0 {
+		return g.f.EndRecord()
+	}
+	return nil
+}
+
+// beginValue names the next element of an enclosing array by its index.
+func (g *Generator) beginValue() {
+	g.sm.ExpectValue()
+	if len(g.frames) == 0 {
+		return
+	}
+	if f := g.top(); f.isArray {
+		f.name = strconv.FormatUint(f.index, 10)
+		f.index++
+	}
+}
+
+func (g *Generator) scalar(str string) []Appender {
+	g.beginValue()
+	defer g.sm.Next()
+	if len(g.frames) == 0 {
+		return g.fail(g.f.RootError())
+	}
+
+	name := g.f.Name(g.names())
+	if _, found := g.seen[name]; found {
+		return g.fail(g.f.DuplicateError(name))
+	}
+	if g.seen == nil {
+		g.seen = make(map[string]struct{})
+	}
+	g.seen[name] = struct{}{}
+	return g.f.Field(name, str)
+}
+
+// names returns the path of keys and array indices leading to the current
+// value.
+func (g *Generator) names() []string {
+	g.path = g.path[:0]
+	for _, f := range g.frames {
+		g.path = append(g.path, f.name)
+	}
+	return g.path
+}
+
+func (g *Generator) fail(err error) []Appender {
+	return []Appender{appenders.Error{Err: err}}
+}
+
+func (g *Generator) top() *frame {
+	return &g.frames[len(g.frames)-1]
+}
+
+type streamGenerator struct {
+	*Generator
+}
+
+func (g streamGenerator) NextDocument() []Appender {
+	g.sm.NextDocument()
+	return nil
+}
+
+var (
+	_ emitter.Generator       = (*Generator)(nil)
+	_ emitter.StreamGenerator = streamGenerator{}
+)
@@ -2,6 +2,7 @@ package emitter
 
 import (
 	"math/big"
+	"time"
 )
 
 type GeneratorFactory interface {
@@ -35,9 +36,57 @@ type Generator interface {
 	InfValue(isNeg bool) []Appender
 	FloatValue(value float64) []Appender
 	BigFloatValue(value *big.Float) []Appender
+	BigRatValue(value *big.Rat) []Appender
+	ComplexValue(value complex128) []Appender
 
 	StringValue(value string) []Appender
 	BytesValue(value []byte) []Appender
 	ByteValue(value byte) []Appender
 	RuneValue(value rune) []Appender
+
+	TimeValue(value time.Time) []Appender
+}
+
+// KeyGenerator is implemented by Generators whose format allows object keys
+// other than strings.  Between StartKey and EndKey, the Generator receives
+// exactly one value (scalar or container) that becomes the key.
+//
+// Generators that do not implement KeyGenerator only ever see string keys:
+// Emitter converts scalar keys to strings as described by FormatKey, and
+// rejects container keys.
+type KeyGenerator interface {
+	Generator
+
+	StartKey() []Appender
+	EndKey() []Appender
+}
+
+// StreamGenerator is implemented by Generators whose format allows more than
+// one top-level value in a single output stream.  NextDocument is called
+// after a complete top-level value, and prepares the Generator to accept
+// another one.
+type StreamGenerator interface {
+	Generator
+
+	NextDocument() []Appender
+}
+
+// TypedArrayGenerator is implemented by Generators whose format has a compact
+// encoding for arrays of fixed-width numbers.  Emitter passes each TypedArray
+// to TypedArrayValue in a single call; other Generators receive it as an
+// ordinary array, one element at a time.
+type TypedArrayGenerator interface {
+	Generator
+
+	TypedArrayValue(value TypedArray) []Appender
+}
+
+// AnnotationGenerator is implemented by Generators whose format can attach
+// annotations, such as Ion type annotations, to a value.  Annotate is called
+// immediately before the value (scalar or container) that the annotations
+// apply to.  Emitter drops annotations for other Generators.
+type AnnotationGenerator interface {
+	Generator
+
+	Annotate(annotations []string) []Appender
 }
@@ -0,0 +1,2 @@
+// Package golit implements Go composite literals for Emitter.
+package golit
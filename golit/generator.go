@@ -0,0 +1,422 @@
+package golit
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+type Appender = appenders.Appender
+
+type key struct {
+	expr   string
+	quoted string
+}
+
+type frame struct {
+	keys  []key
+	elems []string
+}
+
+type item struct {
+	key   string
+	value string
+}
+
+type Generator struct {
+	golit  GoLit
+	sm     states.Machine
+	frames []frame
+}
+
+func (g *Generator) Reset() {
+	g.sm.Reset()
+	g.frames = g.frames[:0]
+}
+
+func (g *Generator) Factory() emitter.GeneratorFactory {
+	return g.golit
+}
+
+func (g *Generator) Begin() []Appender {
+	g.sm.ExpectRoot()
+	return nil
+}
+
+func (g *Generator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+	return []Appender{appenders.LiteralString("\n")}
+}
+
+func (g *Generator) StartObject() []Appender {
+	return g.startContainer(states.ObjectFirstKey)
+}
+
+func (g *Generator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	depth := uint(len(g.frames))
+	f := g.pop()
+
+	allStrings := true
+	for _, k := range f.keys {
+		allStrings = allStrings && k.quoted != ""
+	}
+
+	if !g.golit.Typed {
+		seen := make(map[string]struct{}, len(f.keys))
+		for _, k := range f.keys {
+			if _, found := seen[k.expr]; found {
+				g.sm.Next()
+				return []Appender{appenders.Error{Err: fmt.Errorf("Go map literal cannot repeat key %s", k.expr)}}
+			}
+			seen[k.expr] = struct{}{}
+		}
+	}
+
+	items := make([]item, len(f.elems))
+	for index, value := range f.elems {
+		k := f.keys[index]
+		switch {
+		case !g.golit.Typed && allStrings:
+			items[index] = item{key: k.quoted, value: value}
+		case !g.golit.Typed:
+			items[index] = item{key: k.expr, value: value}
+		case allStrings:
+			items[index] = item{value: "{Key: " + k.quoted + ", Value: " + value + "}"}
+		default:
+			items[index] = item{value: "{Key: " + k.expr + ", Value: " + value + "}"}
+		}
+	}
+
+	var typ string
+	switch {
+	case g.golit.Typed && allStrings:
+		typ = "values.Object"
+	case g.golit.Typed:
+		typ = "values.Map"
+	case allStrings:
+		typ = "map[string]any"
+	default:
+		typ = "map[any]any"
+	}
+	return g.value(g.render(typ, items, depth))
+}
+
+func (g *Generator) StartArray() []Appender {
+	return g.startContainer(states.ArrayFirstValue)
+}
+
+func (g *Generator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	depth := uint(len(g.frames))
+	f := g.pop()
+
+	typ := "[]any"
+	if g.golit.Typed {
+		typ = "values.Array"
+	}
+
+	items := make([]item, len(f.elems))
+	for index, value := range f.elems {
+		items[index] = item{value: value}
+	}
+	return g.value(g.render(typ, items, depth))
+}
+
+func (g *Generator) Key(str string) []Appender {
+	g.sm.ExpectKey()
+	quoted := quoteString(str, g.golit.Backticks)
+	f := g.top()
+	f.keys = append(f.keys, key{expr: g.stringExpr(quoted), quoted: quoted})
+	g.sm.Next()
+	return nil
+}
+
+func (g *Generator) StartKey() []Appender {
+	g.sm.StartKey()
+	return nil
+}
+
+func (g *Generator) EndKey() []Appender {
+	g.sm.EndKey()
+	return nil
+}
+
+func (g *Generator) NullValue() []Appender {
+	if g.golit.Typed {
+		return g.value("values.Null{}")
+	}
+	return g.value("nil")
+}
+
+func (g *Generator) BoolValue(value bool) []Appender {
+	return g.typed("values.Bool", strconv.FormatBool(value))
+}
+
+func (g *Generator) IntValue(value int64) []Appender {
+	return g.typed("values.Int", strconv.FormatInt(value, 10))
+}
+
+func (g *Generator) UintValue(value uint64) []Appender {
+	str := strconv.FormatUint(value, 10)
+	switch {
+	case g.golit.Typed:
+		return g.value("values.Uint(" + str + ")")
+	case value > math.MaxInt64:
+		return g.value("uint64(" + str + ")")
+	default:
+		return g.value(str)
+	}
+}
+
+func (g *Generator) BigIntValue(value *big.Int) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	return g.pointer("values.BigIntValue", bigIntLiteral(value))
+}
+
+func (g *Generator) NaNValue() []Appender {
+	return g.FloatValue(math.NaN())
+}
+
+func (g *Generator) InfValue(isNeg bool) []Appender {
+	if isNeg {
+		return g.FloatValue(math.Inf(-1))
+	}
+	return g.FloatValue(math.Inf(1))
+}
+
+func (g *Generator) FloatValue(value float64) []Appender {
+	return g.typed("values.Float", floatLiteral(value))
+}
+
+func (g *Generator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	return g.pointer("values.BigFloatValue", bigFloatLiteral(value))
+}
+
+func (g *Generator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	return g.pointer("values.BigRatValue", bigRatLiteral(value))
+}
+
+func (g *Generator) ComplexValue(value complex128) []Appender {
+	return g.typed("values.Complex", complexLiteral(value))
+}
+
+func (g *Generator) StringValue(value string) []Appender {
+	quoted := quoteString(value, g.golit.Backticks)
+	return g.emit(g.stringExpr(quoted), quoted)
+}
+
+func (g *Generator) BytesValue(value []byte) []Appender {
+	if g.golit.Typed {
+		return g.value("values.Bytes(" + quoteString(string(value), g.golit.Backticks && utf8.Valid(value)) + ")")
+	}
+	return g.value(bytesLiteral(value))
+}
+
+func (g *Generator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *Generator) RuneValue(value rune) []Appender {
+	return g.StringValue(string(value))
+}
+
+func (g *Generator) TimeValue(value time.Time) []Appender {
+	return g.typed("values.Time", timeLiteral(value))
+}
+
+// startContainer opens a new array or object frame.  Untyped output would
+// use a slice or map as a map key, which compiles but panics at run time, so
+// container keys are only accepted in typed mode.
+func (g *Generator) startContainer(next states.State) []Appender {
+	g.sm.ExpectValue()
+	inKey := g.sm.InKey()
+	g.sm.Push(next)
+	g.frames = append(g.frames, frame{})
+	if inKey && !g.golit.Typed {
+		return []Appender{appenders.Error{Err: fmt.Errorf("Go map keys must be hashable, so they cannot be slices or maps")}}
+	}
+	return nil
+}
+
+func (g *Generator) stringExpr(quoted string) string {
+	if g.golit.Typed {
+		return "values.String(" + quoted + ")"
+	}
+	return quoted
+}
+
+func (g *Generator) typed(constructor string, expr string) []Appender {
+	if g.golit.Typed {
+		expr = constructor + "(" + expr + ")"
+	}
+	return g.value(expr)
+}
+
+func (g *Generator) pointer(constructor string, expr string) []Appender {
+	if g.golit.Typed {
+		expr = constructor + "{Pointer: " + expr + "}"
+	}
+	return g.value(expr)
+}
+
+func (g *Generator) value(expr string) []Appender {
+	return g.emit(expr, "")
+}
+
+// emit delivers a finished expression to the enclosing container (as either
+// a key or a value), or writes it out if it is the root value.  For string
+// values, quoted holds the bare quoted form used for string-keyed literals.
+func (g *Generator) emit(expr string, quoted string) []Appender {
+	g.sm.ExpectValue()
+	defer g.sm.Next()
+
+	if len(g.frames) <= 0 {
+		return []Appender{appenders.LiteralString(expr)}
+	}
+
+	f := g.top()
+	if g.sm.State.In(states.KeyRoot) {
+		f.keys = append(f.keys, key{expr: expr, quoted: quoted})
+		return nil
+	}
+	f.elems = append(f.elems, expr)
+	return nil
+}
+
+// render formats a composite literal of the given type.  In multi-line mode
+// each item goes on its own line, and the keys of consecutive single-line
+// items are padded into a column the same way gofmt aligns them.
+func (g *Generator) render(typ string, items []item, depth uint) string {
+	var sb strings.Builder
+	sb.WriteString(typ)
+	sb.WriteByte('{')
+	if len(items) == 0 {
+		sb.WriteByte('}')
+		return sb.String()
+	}
+
+	if !g.golit.MultiLine {
+		for index, it := range items {
+			if index > 0 {
+				sb.WriteString(", ")
+			}
+			if it.key != "" {
+				sb.WriteString(it.key)
+				sb.WriteString(": ")
+			}
+			sb.WriteString(it.value)
+		}
+		sb.WriteByte('}')
+		return sb.String()
+	}
+
+	widths := alignment(items)
+	for index, it := range items {
+		sb.WriteByte('\n')
+		sb.WriteString(strings.Repeat("\t", int(depth)))
+		if it.key != "" {
+			sb.WriteString(it.key)
+			sb.WriteByte(':')
+			sb.WriteString(strings.Repeat(" ", 1+widths[index]-utf8.RuneCountInString(it.key)))
+		}
+		sb.WriteString(it.value)
+		sb.WriteByte(',')
+	}
+	sb.WriteByte('\n')
+	sb.WriteString(strings.Repeat("\t", int(depth-1)))
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// alignment computes the key column width for each item, following the
+// section-breaking rules of go/printer: multi-line items stand alone, and
+// a long key whose size differs sharply from the running geometric mean
+// starts a new section.  As in go/printer, the section decision uses byte
+// sizes and restarts the running mean with each section, while the column
+// width counts runes the way text/tabwriter does.
+func alignment(items []item) []int {
+	const smallSize = 40
+	const r = 2.5
+
+	widths := make([]int, len(items))
+	start := 0
+	flush := func(end int) {
+		width := 0
+		for i := start; i < end; i++ {
+			width = max(width, utf8.RuneCountInString(items[i].key))
+		}
+		for i := start; i < end; i++ {
+			widths[i] = width
+		}
+		start = end
+	}
+
+	var lnsum float64
+	var count int
+	var size int
+	for index, it := range items {
+		prevSize := size
+		size = 0
+		if !strings.Contains(it.value, "\n") {
+			size = len(it.key)
+		}
+
+		useFF := true
+		if prevSize > 0 && size > 0 {
+			if count == 0 || prevSize <= smallSize && size <= smallSize {
+				useFF = false
+			} else {
+				geomean := math.Exp(lnsum / float64(count))
+				ratio := float64(size) / geomean
+				useFF = r*ratio <= 1 || r <= ratio
+			}
+		}
+		if index > 0 && useFF {
+			flush(index)
+			lnsum = 0
+			count = 0
+		}
+
+		if size > 0 {
+			lnsum += math.Log(float64(size))
+			count++
+		}
+	}
+	flush(len(items))
+	return widths
+}
+
+func (g *Generator) pop() frame {
+	n := len(g.frames) - 1
+	f := g.frames[n]
+	g.frames = g.frames[:n]
+	g.sm.Pop()
+	return f
+}
+
+func (g *Generator) top() *frame {
+	return &g.frames[len(g.frames)-1]
+}
+
+var (
+	_ emitter.Generator    = (*Generator)(nil)
+	_ emitter.KeyGenerator = (*Generator)(nil)
+)
@@ -0,0 +1,19 @@
+package golit
+
+import (
+	"github.com/chronos-tachyon/go-emitter"
+)
+
+type GoLit struct {
+	Typed     bool
+	Backticks bool
+	MultiLine bool
+}
+
+func (golit GoLit) NewGenerator() emitter.Generator {
+	g := &Generator{golit: golit}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = GoLit{}
@@ -0,0 +1,224 @@
+package golit
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+func TestGoLit(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Input     Value
+		Factory   emitter.GeneratorFactory
+		Expect    string
+		ExpectErr error
+	}
+
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	kFancyValue := values.Object{
+		{Key: "id", Value: values.Int(7)},
+		{Key: "name", Value: values.String(`say "hi"`)},
+		{Key: "tags", Value: values.Array{values.Float(1), values.Float(math.NaN()), values.Null{}}},
+		{Key: "ok", Value: values.Bool(true)},
+		{Key: "longer_key", Value: values.Bytes("ab")},
+		{Key: "empty", Value: values.Object(nil)},
+	}
+
+	testData := [...]testCase{
+		{
+			Name:    "Untyped",
+			Input:   kFancyValue,
+			Factory: GoLit{},
+			Expect:  `map[string]any{"id": 7, "name": "say \"hi\"", "tags": []any{1.0, math.NaN(), nil}, "ok": true, "longer_key": []byte{0x61, 0x62}, "empty": map[string]any{}}` + "\n",
+		},
+		{
+			Name:    "Typed",
+			Input:   kFancyValue,
+			Factory: GoLit{Typed: true, Backticks: true},
+			Expect:  "values.Object{{Key: `id`, Value: values.Int(7)}, {Key: `name`, Value: values.String(`say \"hi\"`)}, {Key: `tags`, Value: values.Array{values.Float(1.0), values.Float(math.NaN()), values.Null{}}}, {Key: `ok`, Value: values.Bool(true)}, {Key: `longer_key`, Value: values.Bytes(`ab`)}, {Key: `empty`, Value: values.Object{}}}\n",
+		},
+		{
+			Name:    "MultiLine",
+			Input:   kFancyValue,
+			Factory: GoLit{MultiLine: true},
+			Expect: "" +
+				"map[string]any{\n" +
+				"\t\"id\":   7,\n" +
+				"\t\"name\": \"say \\\"hi\\\"\",\n" +
+				"\t\"tags\": []any{\n" +
+				"\t\t1.0,\n" +
+				"\t\tmath.NaN(),\n" +
+				"\t\tnil,\n" +
+				"\t},\n" +
+				"\t\"ok\":         true,\n" +
+				"\t\"longer_key\": []byte{0x61, 0x62},\n" +
+				"\t\"empty\":      map[string]any{},\n" +
+				"}\n",
+		},
+		{
+			Name:    "TypedMultiLine",
+			Input:   kFancyValue,
+			Factory: GoLit{Typed: true, MultiLine: true},
+			Expect: "" +
+				"values.Object{\n" +
+				"\t{Key: \"id\", Value: values.Int(7)},\n" +
+				"\t{Key: \"name\", Value: values.String(\"say \\\"hi\\\"\")},\n" +
+				"\t{Key: \"tags\", Value: values.Array{\n" +
+				"\t\tvalues.Float(1.0),\n" +
+				"\t\tvalues.Float(math.NaN()),\n" +
+				"\t\tvalues.Null{},\n" +
+				"\t}},\n" +
+				"\t{Key: \"ok\", Value: values.Bool(true)},\n" +
+				"\t{Key: \"longer_key\", Value: values.Bytes(\"ab\")},\n" +
+				"\t{Key: \"empty\", Value: values.Object{}},\n" +
+				"}\n",
+		},
+		{
+			Name: "Numbers",
+			Input: values.Array{
+				values.Uint(math.MaxUint64),
+				values.BigIntValue{Pointer: big.NewInt(-5)},
+				values.BigIntValue{Pointer: huge},
+				values.BigRatValue{Pointer: big.NewRat(1, 3)},
+				values.Complex(complex(1.5, -2)),
+				values.Time(time.Date(2023, 9, 1, 12, 30, 0, 500, time.UTC)),
+			},
+			Factory: GoLit{},
+			Expect:  `[]any{uint64(18446744073709551615), big.NewInt(-5), func() *big.Int { z, _ := new(big.Int).SetString("123456789012345678901234567890", 10); return z }(), big.NewRat(1, 3), complex(1.5, -2.0), time.Date(2023, time.September, 1, 12, 30, 0, 500, time.UTC)}` + "\n",
+		},
+		{
+			Name: "Map",
+			Input: values.Map{
+				{Key: values.Int(1), Value: values.String("one")},
+				{Key: values.String("two"), Value: values.Int(2)},
+			},
+			Factory: GoLit{Typed: true},
+			Expect:  `values.Map{{Key: values.Int(1), Value: values.String("one")}, {Key: values.String("two"), Value: values.Int(2)}}` + "\n",
+		},
+		{
+			Name:      "Duplicate",
+			Input:     values.Object{{Key: "a", Value: values.Int(1)}, {Key: "a", Value: values.Int(2)}},
+			Factory:   GoLit{},
+			ExpectErr: fmt.Errorf("Go map literal cannot repeat key %s", `"a"`),
+		},
+		{
+			Name:    "Duplicate/Typed",
+			Input:   values.Object{{Key: "a", Value: values.Int(1)}, {Key: "a", Value: values.Int(2)}},
+			Factory: GoLit{Typed: true},
+			Expect:  `values.Object{{Key: "a", Value: values.Int(1)}, {Key: "a", Value: values.Int(2)}}` + "\n",
+		},
+		{
+			Name: "LongKeys",
+			Input: values.Object{
+				{Key: "a", Value: values.Int(1)},
+				{Key: "this_is_a_very_long_key_name_exceeding_forty_chars_total", Value: values.Int(2)},
+				{Key: "b", Value: values.Int(3)},
+			},
+			Factory: GoLit{MultiLine: true},
+			Expect: "" +
+				"map[string]any{\n" +
+				"\t\"a\": 1,\n" +
+				"\t\"this_is_a_very_long_key_name_exceeding_forty_chars_total\": 2,\n" +
+				"\t\"b\": 3,\n" +
+				"}\n",
+		},
+		{
+			Name: "LongKeys/Reset",
+			Input: values.Object{
+				{Key: "a", Value: values.Int(1)},
+				{Key: "b", Value: values.Int(1)},
+				{Key: "c", Value: values.Int(1)},
+				{Key: "d", Value: values.Int(1)},
+				{Key: "this_is_a_very_long_key_name_exceeding_forty_chars_total", Value: values.Int(2)},
+				{Key: "z", Value: values.Float(2)},
+				{Key: "yy", Value: values.Float(3)},
+			},
+			Factory: GoLit{MultiLine: true},
+			Expect: "" +
+				"map[string]any{\n" +
+				"\t\"a\": 1,\n" +
+				"\t\"b\": 1,\n" +
+				"\t\"c\": 1,\n" +
+				"\t\"d\": 1,\n" +
+				"\t\"this_is_a_very_long_key_name_exceeding_forty_chars_total\": 2,\n" +
+				"\t\"z\":  2.0,\n" +
+				"\t\"yy\": 3.0,\n" +
+				"}\n",
+		},
+		{
+			Name: "NonASCIIKeys",
+			Input: values.Object{
+				{Key: "héllö", Value: values.Int(1)},
+				{Key: "abcdefghij", Value: values.Int(2)},
+			},
+			Factory: GoLit{MultiLine: true},
+			Expect: "" +
+				"map[string]any{\n" +
+				"\t\"héllö\":      1,\n" +
+				"\t\"abcdefghij\": 2,\n" +
+				"}\n",
+		},
+		{
+			Name: "ContainerKey",
+			Input: values.Map{
+				{Key: values.Array{values.Int(1)}, Value: values.Int(3)},
+			},
+			Factory:   GoLit{},
+			ExpectErr: fmt.Errorf("Go map keys must be hashable, so they cannot be slices or maps"),
+		},
+		{
+			Name: "ContainerKey/Typed",
+			Input: values.Map{
+				{Key: values.Array{values.Int(1)}, Value: values.Int(3)},
+			},
+			Factory: GoLit{Typed: true},
+			Expect:  `values.Map{{Key: values.Array{values.Int(1)}, Value: values.Int(3)}}` + "\n",
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, row.Factory.NewGenerator())
+			row.Input.EmitTo(&e)
+			err := e.Close()
+
+			if !reflect.DeepEqual(err, row.ExpectErr) {
+				t.Errorf("wrong error:\n\texpect: %#v\n\tactual: %#v", row.ExpectErr, err)
+				return
+			}
+
+			actual := buf.String()
+			if actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect:\n%s\n\tactual:\n%s", row.Expect, actual)
+			}
+
+			if row.ExpectErr != nil || !row.Factory.(GoLit).MultiLine {
+				return
+			}
+			src := "package fixture\n\nvar x = " + actual
+			formatted, err := format.Source([]byte(src))
+			if err != nil {
+				t.Errorf("gofmt failed: %v", err)
+				return
+			}
+			if string(formatted) != src {
+				t.Errorf("not gofmt-stable:\n\texpect:\n%s\n\tactual:\n%s", formatted, src)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package golit
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func quoteString(str string, backticks bool) string {
+	if backticks && strconv.CanBackquote(str) {
+		return "`" + str + "`"
+	}
+	return strconv.Quote(str)
+}
+
+func floatLiteral(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "math.NaN()"
+	case math.IsInf(value, 1):
+		return "math.Inf(1)"
+	case math.IsInf(value, -1):
+		return "math.Inf(-1)"
+	case value == 0 && math.Signbit(value):
+		return "math.Copysign(0, -1)"
+	}
+
+	str := strconv.FormatFloat(value, 'g', -1, 64)
+	if !strings.ContainsAny(str, ".e") {
+		str += ".0"
+	}
+	return str
+}
+
+func bigIntLiteral(value *big.Int) string {
+	if value.IsInt64() {
+		return fmt.Sprintf("big.NewInt(%d)", value.Int64())
+	}
+	return fmt.Sprintf("func() *big.Int { z, _ := new(big.Int).SetString(%q, 10); return z }()", value.String())
+}
+
+func bigFloatLiteral(value *big.Float) string {
+	if value.IsInf() {
+		return fmt.Sprintf("new(big.Float).SetInf(%t)", value.Signbit())
+	}
+	if f, acc := value.Float64(); acc == big.Exact && value.Prec() == 53 {
+		return fmt.Sprintf("big.NewFloat(%s)", floatLiteral(f))
+	}
+	return fmt.Sprintf("func() *big.Float { z, _ := new(big.Float).SetPrec(%d).SetString(%q); return z }()", value.Prec(), value.Text('g', -1))
+}
+
+func bigRatLiteral(value *big.Rat) string {
+	if value.Num().IsInt64() && value.Denom().IsInt64() {
+		return fmt.Sprintf("big.NewRat(%d, %d)", value.Num().Int64(), value.Denom().Int64())
+	}
+	return fmt.Sprintf("func() *big.Rat { z, _ := new(big.Rat).SetString(%q); return z }()", value.String())
+}
+
+func complexLiteral(value complex128) string {
+	return fmt.Sprintf("complex(%s, %s)", floatLiteral(real(value)), floatLiteral(imag(value)))
+}
+
+func bytesLiteral(value []byte) string {
+	var sb strings.Builder
+	sb.WriteString("[]byte{")
+	for index, ch := range value {
+		if index > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "0x%02x", ch)
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+func timeLiteral(value time.Time) string {
+	loc := "time.UTC"
+	if name, offset := value.Zone(); value.Location() != time.UTC {
+		loc = fmt.Sprintf("time.FixedZone(%q, %d)", name, offset)
+	}
+	return fmt.Sprintf("time.Date(%d, time.%s, %d, %d, %d, %d, %d, %s)",
+		value.Year(), value.Month(), value.Day(),
+		value.Hour(), value.Minute(), value.Second(), value.Nanosecond(), loc)
+}
@@ -0,0 +1,82 @@
+package html
+
+import (
+	"fmt"
+
+	"github.com/chronos-tachyon/go-emitter/appenders"
+)
+
+type Appender = appenders.Appender
+
+type SpanAppender struct {
+	Class string
+	ID    string
+	Text  string
+}
+
+func (a SpanAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a SpanAppender) Append(out []byte) []byte {
+	out = append(out, `<span class="`...)
+	out = AppendEscaped(out, a.Class)
+	out = append(out, '"')
+	if a.ID != "" {
+		out = append(out, ` id="`...)
+		out = AppendEscaped(out, a.ID)
+		out = append(out, '"')
+	}
+	out = append(out, '>')
+	out = AppendEscaped(out, a.Text)
+	return append(out, "</span>"...)
+}
+
+var (
+	_ fmt.Stringer = SpanAppender{}
+	_ Appender     = SpanAppender{}
+)
+
+// AppendEscaped appends str with all HTML-significant characters replaced by
+// character references, making it safe in both text and attribute values.
+func AppendEscaped(out []byte, str string) []byte {
+	for i := 0; i < len(str); i++ {
+		switch ch := str[i]; ch {
+		case '&':
+			out = append(out, "&amp;"...)
+		case '<':
+			out = append(out, "&lt;"...)
+		case '>':
+			out = append(out, "&gt;"...)
+		case '"':
+			out = append(out, "&quot;"...)
+		case '\'':
+			out = append(out, "&#39;"...)
+		default:
+			out = append(out, ch)
+		}
+	}
+	return out
+}
+
+// AppendPathSegment appends str as one segment of an anchor path, keeping
+// only characters that are unambiguous in a URL fragment.
+func AppendPathSegment(out []byte, str string) []byte {
+	const hexDigits = "0123456789ABCDEF"
+	for i := 0; i < len(str); i++ {
+		ch := str[i]
+		switch {
+		case ch >= 'a' && ch <= 'z':
+			out = append(out, ch)
+		case ch >= 'A' && ch <= 'Z':
+			out = append(out, ch)
+		case ch >= '0' && ch <= '9':
+			out = append(out, ch)
+		case ch == '-' || ch == '_' || ch == '~':
+			out = append(out, ch)
+		default:
+			out = append(out, '%', hexDigits[ch>>4], hexDigits[ch&15])
+		}
+	}
+	return out
+}
@@ -0,0 +1,2 @@
+// Package html implements syntax-highlighted HTML rendering of JSON for Emitter.
+package html
@@ -0,0 +1,314 @@
+package html
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/json"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+const (
+	classKey    = "key"
+	classString = "string"
+	classNumber = "number"
+	classBool   = "bool"
+	classNull   = "null"
+	classPunct  = "punct"
+)
+
+type frame struct {
+	isArray bool
+	index   uint64
+	path    []byte
+}
+
+type Generator struct {
+	html   HTML
+	sm     states.Machine
+	frames []frame
+	path   []byte
+}
+
+func (g *Generator) Reset() {
+	g.sm.Reset()
+	g.frames = g.frames[:0]
+	g.path = g.path[:0]
+}
+
+func (g *Generator) Factory() emitter.GeneratorFactory {
+	return g.html
+}
+
+func (g *Generator) Begin() []Appender {
+	g.sm.ExpectRoot()
+
+	var b appenders.Builder
+	b.AddString("<" + g.wrapper() + ` class="`)
+	b.AddBytes(AppendEscaped(nil, g.html.ClassPrefix+"json"))
+	b.AddString(`"`)
+	if g.html.Collapsible {
+		b.AddString(` style="white-space: pre"`)
+	}
+	b.AddString(`>`)
+	return b.Build()
+}
+
+func (g *Generator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+
+	var b appenders.Builder
+	b.AddString("</" + g.wrapper() + ">")
+	g.html.Format.LineFeed(&b)
+	return b.Build()
+}
+
+func (g *Generator) StartObject() []Appender {
+	return g.startContainer(false, "{", states.ObjectFirstKey)
+}
+
+func (g *Generator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	return g.endContainer("}", states.ObjectNextKey)
+}
+
+func (g *Generator) StartArray() []Appender {
+	return g.startContainer(true, "[", states.ArrayFirstValue)
+}
+
+func (g *Generator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	return g.endContainer("]", states.ArrayNextValue)
+}
+
+func (g *Generator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+
+	var b appenders.Builder
+	if g.sm.State.In(states.ObjectFirstKey) {
+		g.indent(&b)
+	}
+	if g.sm.State.In(states.ObjectNextKey) {
+		g.span(&b, classPunct, ",", "")
+		g.html.Format.IndentOrSpace(&b, g.html.IndentWithTabs, g.html.IndentSize, g.sm.Depth())
+	}
+
+	g.path = append(g.path[:0], g.top().path...)
+	g.path = append(g.path, '.')
+	g.path = AppendPathSegment(g.path, key)
+
+	text := json.StringAppender{Value: key}.String()
+	g.span(&b, classKey, text, g.anchor())
+	g.span(&b, classPunct, ":", "")
+	g.html.Format.Space(&b)
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) NullValue() []Appender {
+	return g.value(classNull, "null")
+}
+
+func (g *Generator) BoolValue(value bool) []Appender {
+	return g.value(classBool, strconv.FormatBool(value))
+}
+
+func (g *Generator) IntValue(value int64) []Appender {
+	return g.value(classNumber, strconv.FormatInt(value, 10))
+}
+
+func (g *Generator) UintValue(value uint64) []Appender {
+	return g.value(classNumber, strconv.FormatUint(value, 10))
+}
+
+func (g *Generator) BigIntValue(value *big.Int) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	return g.value(classNumber, value.String())
+}
+
+func (g *Generator) NaNValue() []Appender {
+	return g.value(classString, `"NaN"`)
+}
+
+func (g *Generator) InfValue(isNeg bool) []Appender {
+	if isNeg {
+		return g.value(classString, `"-Inf"`)
+	}
+	return g.value(classString, `"+Inf"`)
+}
+
+func (g *Generator) FloatValue(value float64) []Appender {
+	return g.value(classNumber, string(appenders.FloatText(value).Append(nil)))
+}
+
+func (g *Generator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	return g.value(classNumber, string(appenders.BigFloatText{Pointer: value}.Append(nil)))
+}
+
+func (g *Generator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if _, ok := appenders.TerminatingDigits(value); ok {
+		return g.value(classNumber, string(appenders.BigRatDecimalText{Pointer: value}.Append(nil)))
+	}
+	return g.StringValue(string(appenders.BigRatText{Pointer: value}.Append(nil)))
+}
+
+func (g *Generator) ComplexValue(value complex128) []Appender {
+	var list []Appender
+	list = append(list, g.StartObject()...)
+	list = append(list, g.Key("re")...)
+	list = append(list, g.floatValue(real(value))...)
+	list = append(list, g.Key("im")...)
+	list = append(list, g.floatValue(imag(value))...)
+	list = append(list, g.EndObject()...)
+	return list
+}
+
+func (g *Generator) StringValue(value string) []Appender {
+	return g.value(classString, json.StringAppender{Value: value}.String())
+}
+
+func (g *Generator) BytesValue(value []byte) []Appender {
+	return g.value(classString, json.BytesAppender{Value: value}.String())
+}
+
+func (g *Generator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *Generator) RuneValue(value rune) []Appender {
+	return g.StringValue(string(value))
+}
+
+func (g *Generator) TimeValue(value time.Time) []Appender {
+	return g.StringValue(value.Format(time.RFC3339Nano))
+}
+
+func (g *Generator) floatValue(value float64) []Appender {
+	switch {
+	case math.IsNaN(value):
+		return g.NaNValue()
+	case math.IsInf(value, 0):
+		return g.InfValue(value < 0)
+	default:
+		return g.FloatValue(value)
+	}
+}
+
+// wrapper returns the element that holds the document.  A <pre> may only
+// contain phrasing content, so collapsible output, which uses <details>,
+// is wrapped in a <div> styled to preserve white space instead.
+func (g *Generator) wrapper() string {
+	if g.html.Collapsible {
+		return "div"
+	}
+	return "pre"
+}
+
+func (g *Generator) startContainer(isArray bool, open string, next states.State) []Appender {
+	g.sm.ExpectValue()
+
+	var b appenders.Builder
+	id := g.beginValue(&b)
+	path := append([]byte(nil), g.path...)
+	if g.html.Collapsible {
+		b.AddString("<details open><summary>")
+	}
+	g.span(&b, classPunct, open, id)
+	if g.html.Collapsible {
+		b.AddString("</summary>")
+	}
+
+	g.sm.Push(next)
+	g.frames = append(g.frames, frame{isArray: isArray, path: path})
+	return b.Build()
+}
+
+func (g *Generator) endContainer(close string, nonEmpty states.State) []Appender {
+	needIndent := g.sm.State.In(nonEmpty)
+	g.frames = g.frames[:len(g.frames)-1]
+	g.sm.Pop()
+
+	var b appenders.Builder
+	if needIndent {
+		g.indent(&b)
+	}
+	g.span(&b, classPunct, close, "")
+	if g.html.Collapsible {
+		b.AddString("</details>")
+	}
+	g.sm.Next()
+	return b.Build()
+}
+
+// beginValue writes the separator before a value and returns the anchor for
+// it, unless the anchor was already attached to the value's key.
+func (g *Generator) beginValue(b *appenders.Builder) string {
+	switch {
+	case g.sm.State.In(states.Root):
+		g.path = g.path[:0]
+		return g.anchor()
+
+	case g.sm.State.In(states.ArrayFirstValue, states.ArrayNextValue):
+		if g.sm.State.In(states.ArrayFirstValue) {
+			g.indent(b)
+		} else {
+			g.span(b, classPunct, ",", "")
+			g.html.Format.IndentOrSpace(b, g.html.IndentWithTabs, g.html.IndentSize, g.sm.Depth())
+		}
+		f := g.top()
+		g.path = append(g.path[:0], f.path...)
+		g.path = append(g.path, '.')
+		g.path = strconv.AppendUint(g.path, f.index, 10)
+		f.index++
+		return g.anchor()
+	}
+	return ""
+}
+
+func (g *Generator) value(class string, text string) []Appender {
+	g.sm.ExpectValue()
+
+	var b appenders.Builder
+	id := g.beginValue(&b)
+	g.span(&b, class, text, id)
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) span(b *appenders.Builder, class string, text string, id string) {
+	b.Add(SpanAppender{Class: g.html.ClassPrefix + class, ID: id, Text: text})
+}
+
+func (g *Generator) anchor() string {
+	if !g.html.Anchors {
+		return ""
+	}
+	prefix := g.html.AnchorPrefix
+	if prefix == "" {
+		prefix = "json"
+	}
+	return prefix + string(g.path)
+}
+
+func (g *Generator) indent(b *appenders.Builder) {
+	g.html.Format.Indent(b, g.html.IndentWithTabs, g.html.IndentSize, g.sm.Depth())
+}
+
+func (g *Generator) top() *frame {
+	return &g.frames[len(g.frames)-1]
+}
+
+var _ emitter.Generator = (*Generator)(nil)
@@ -0,0 +1,24 @@
+package html
+
+import (
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/json"
+)
+
+type HTML struct {
+	Format         json.Format
+	IndentSize     uint
+	IndentWithTabs bool
+	ClassPrefix    string
+	Collapsible    bool
+	Anchors        bool
+	AnchorPrefix   string
+}
+
+func (html HTML) NewGenerator() emitter.Generator {
+	g := &Generator{html: html}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = HTML{}
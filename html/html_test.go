@@ -0,0 +1,81 @@
+package html
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/json"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+func TestHTML(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Input     Value
+		Factory   emitter.GeneratorFactory
+		Expect    string
+		ExpectErr error
+	}
+
+	kFancyValue := values.Object{
+		{Key: "a b", Value: values.Array{values.Int(1), values.String("<x & 'y'>")}},
+		{Key: "ok", Value: values.Bool(true)},
+		{Key: "none", Value: values.Null{}},
+	}
+
+	testData := [...]testCase{
+		{
+			Name:    "Scalar",
+			Input:   values.Float(0.5),
+			Factory: HTML{},
+			Expect:  `<pre class="json"><span class="number">0.5</span></pre>`,
+		},
+		{
+			Name:    "Compact",
+			Input:   kFancyValue,
+			Factory: HTML{ClassPrefix: "j-"},
+			Expect:  `<pre class="j-json"><span class="j-punct">{</span><span class="j-key">&quot;a b&quot;</span><span class="j-punct">:</span><span class="j-punct">[</span><span class="j-number">1</span><span class="j-punct">,</span><span class="j-string">&quot;&lt;x &amp; &#39;y&#39;&gt;&quot;</span><span class="j-punct">]</span><span class="j-punct">,</span><span class="j-key">&quot;ok&quot;</span><span class="j-punct">:</span><span class="j-bool">true</span><span class="j-punct">,</span><span class="j-key">&quot;none&quot;</span><span class="j-punct">:</span><span class="j-null">null</span><span class="j-punct">}</span></pre>`,
+		},
+		{
+			Name:    "Anchors",
+			Input:   kFancyValue,
+			Factory: HTML{Anchors: true, Format: json.OneLine},
+			Expect:  `<pre class="json"><span class="punct" id="json">{</span><span class="key" id="json.a%20b">&quot;a b&quot;</span><span class="punct">:</span> <span class="punct">[</span><span class="number" id="json.a%20b.0">1</span><span class="punct">,</span> <span class="string" id="json.a%20b.1">&quot;&lt;x &amp; &#39;y&#39;&gt;&quot;</span><span class="punct">]</span><span class="punct">,</span> <span class="key" id="json.ok">&quot;ok&quot;</span><span class="punct">:</span> <span class="bool">true</span><span class="punct">,</span> <span class="key" id="json.none">&quot;none&quot;</span><span class="punct">:</span> <span class="null">null</span><span class="punct">}</span></pre>` + "\n",
+		},
+		{
+			Name:    "Collapsible",
+			Input:   values.Array{values.Object{{Key: "k", Value: values.String("v")}}},
+			Factory: HTML{Collapsible: true, Format: json.MultiLine, IndentSize: 2},
+			Expect: "" +
+				`<div class="json" style="white-space: pre"><details open><summary><span class="punct">[</span></summary>` + "\n" +
+				`  <details open><summary><span class="punct">{</span></summary>` + "\n" +
+				`    <span class="key">&quot;k&quot;</span><span class="punct">:</span> <span class="string">&quot;v&quot;</span>` + "\n" +
+				`  <span class="punct">}</span></details>` + "\n" +
+				`<span class="punct">]</span></details></div>` + "\n",
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, row.Factory.NewGenerator())
+			row.Input.EmitTo(&e)
+			err := e.Close()
+
+			if !reflect.DeepEqual(err, row.ExpectErr) {
+				t.Errorf("wrong error:\n\texpect: %#v\n\tactual: %#v", row.ExpectErr, err)
+				return
+			}
+
+			if actual := buf.String(); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect:\n%s\n\tactual:\n%s", row.Expect, actual)
+			}
+		})
+	}
+}
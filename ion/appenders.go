@@ -0,0 +1,156 @@
+package ion
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/chronos-tachyon/go-emitter/appenders"
+)
+
+type Appender = appenders.Appender
+
+// IsIdentifier reports whether str can be written as an unquoted Ion symbol.
+// Keywords and names starting with '$' (which could be read as symbol IDs or
+// system symbols) are excluded.
+func IsIdentifier(str string) bool {
+	switch str {
+	case "", "null", "true", "false", "nan":
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		ch := str[i]
+		switch {
+		case ch >= 'a' && ch <= 'z':
+		case ch >= 'A' && ch <= 'Z':
+		case ch == '_':
+		case ch >= '0' && ch <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+type StringAppender struct {
+	Value string
+}
+
+func (a StringAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a StringAppender) Append(out []byte) []byte {
+	return appendQuoted(out, a.Value, '"')
+}
+
+var (
+	_ fmt.Stringer = StringAppender{}
+	_ Appender     = StringAppender{}
+)
+
+type SymbolAppender struct {
+	Value string
+}
+
+func (a SymbolAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a SymbolAppender) Append(out []byte) []byte {
+	if IsIdentifier(a.Value) {
+		return append(out, a.Value...)
+	}
+	return appendQuoted(out, a.Value, '\'')
+}
+
+var (
+	_ fmt.Stringer = SymbolAppender{}
+	_ Appender     = SymbolAppender{}
+)
+
+type FloatAppender float64
+
+func (a FloatAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a FloatAppender) Append(out []byte) []byte {
+	value := float64(a)
+	switch {
+	case math.IsNaN(value):
+		return append(out, "nan"...)
+	case math.IsInf(value, 1):
+		return append(out, "+inf"...)
+	case math.IsInf(value, -1):
+		return append(out, "-inf"...)
+	}
+
+	start := len(out)
+	out = strconv.AppendFloat(out, value, 'g', -1, 64)
+	if strings.IndexByte(string(out[start:]), 'e') < 0 {
+		out = append(out, 'e', '0')
+	}
+	return out
+}
+
+var (
+	_ fmt.Stringer = FloatAppender(0)
+	_ Appender     = FloatAppender(0)
+)
+
+type BlobAppender struct {
+	Value []byte
+}
+
+func (a BlobAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a BlobAppender) Append(out []byte) []byte {
+	out = append(out, '{', '{')
+	out = append(out, base64.StdEncoding.EncodeToString(a.Value)...)
+	return append(out, '}', '}')
+}
+
+var (
+	_ fmt.Stringer = BlobAppender{}
+	_ Appender     = BlobAppender{}
+)
+
+func appendQuoted(out []byte, str string, quote byte) []byte {
+	out = append(out, quote)
+	for _, ch := range str {
+		switch ch {
+		case 0:
+			out = append(out, '\\', '0')
+		case '\a':
+			out = append(out, '\\', 'a')
+		case '\b':
+			out = append(out, '\\', 'b')
+		case '\t':
+			out = append(out, '\\', 't')
+		case '\n':
+			out = append(out, '\\', 'n')
+		case '\v':
+			out = append(out, '\\', 'v')
+		case '\f':
+			out = append(out, '\\', 'f')
+		case '\r':
+			out = append(out, '\\', 'r')
+		case '\\':
+			out = append(out, '\\', '\\')
+		case rune(quote):
+			out = append(out, '\\', quote)
+		default:
+			if ch < 0x20 || ch == 0x7f {
+				out = fmt.Appendf(out, "\\x%02x", ch)
+				continue
+			}
+			out = append(out, string(ch)...)
+		}
+	}
+	return append(out, quote)
+}
@@ -0,0 +1,306 @@
+package ion
+
+import (
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+// container records where an unfinished list or struct begins in buf, and
+// the annotations that will wrap it.
+type container struct {
+	typ         byte
+	start       int
+	annotations []uint64
+}
+
+type BinaryGenerator struct {
+	binary      Binary
+	sm          states.Machine
+	buf         []byte
+	stack       []container
+	annotations []uint64
+	symbols     map[string]uint64
+	newSymbols  []string
+	nextSID     uint64
+	declared    bool
+}
+
+func (g *BinaryGenerator) Reset() {
+	g.sm.Reset()
+	g.buf = g.buf[:0]
+	g.stack = g.stack[:0]
+	g.annotations = nil
+	g.newSymbols = g.newSymbols[:0]
+	g.declared = false
+
+	g.symbols = make(map[string]uint64, len(systemSymbols))
+	for index, name := range systemSymbols {
+		g.symbols[name] = uint64(index) + 1
+	}
+	g.nextSID = firstLocalSID
+}
+
+func (g *BinaryGenerator) Factory() emitter.GeneratorFactory {
+	return g.binary
+}
+
+func (g *BinaryGenerator) Begin() []Appender {
+	g.sm.ExpectRoot()
+	return []Appender{appenders.LiteralBytes(versionMarker[:])}
+}
+
+func (g *BinaryGenerator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+	return nil
+}
+
+func (g *BinaryGenerator) NextDocument() []Appender {
+	g.sm.NextDocument()
+	return nil
+}
+
+func (g *BinaryGenerator) StartObject() []Appender {
+	return g.startContainer(typeStruct, states.ObjectFirstKey)
+}
+
+func (g *BinaryGenerator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	return g.endContainer()
+}
+
+func (g *BinaryGenerator) StartArray() []Appender {
+	return g.startContainer(typeList, states.ArrayFirstValue)
+}
+
+func (g *BinaryGenerator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	return g.endContainer()
+}
+
+func (g *BinaryGenerator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+	g.buf = appendVarUInt(g.buf, g.symbol(key))
+	g.sm.Next()
+	return nil
+}
+
+func (g *BinaryGenerator) Annotate(annotations []string) []Appender {
+	g.sm.ExpectValue()
+	for _, annotation := range annotations {
+		g.annotations = append(g.annotations, g.symbol(annotation))
+	}
+	return nil
+}
+
+func (g *BinaryGenerator) NullValue() []Appender {
+	return g.value(append(g.buf, typeNull<<4|lengthNull))
+}
+
+func (g *BinaryGenerator) BoolValue(value bool) []Appender {
+	if value {
+		return g.value(append(g.buf, typeBool<<4|1))
+	}
+	return g.value(append(g.buf, typeBool<<4))
+}
+
+func (g *BinaryGenerator) IntValue(value int64) []Appender {
+	return g.value(appendInt(g.buf, value))
+}
+
+func (g *BinaryGenerator) UintValue(value uint64) []Appender {
+	return g.value(appendUint(g.buf, value))
+}
+
+func (g *BinaryGenerator) BigIntValue(value *big.Int) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	return g.value(appendBigInt(g.buf, value))
+}
+
+func (g *BinaryGenerator) NaNValue() []Appender {
+	return g.value(appendFloat(g.buf, math.NaN()))
+}
+
+func (g *BinaryGenerator) InfValue(isNeg bool) []Appender {
+	if isNeg {
+		return g.value(appendFloat(g.buf, math.Inf(-1)))
+	}
+	return g.value(appendFloat(g.buf, math.Inf(1)))
+}
+
+func (g *BinaryGenerator) FloatValue(value float64) []Appender {
+	return g.value(appendFloat(g.buf, value))
+}
+
+func (g *BinaryGenerator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if value.IsInf() {
+		return g.InfValue(value.Signbit())
+	}
+	return g.value(appendDecimal(g.buf, value.Text('g', -1)))
+}
+
+func (g *BinaryGenerator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if digits, ok := appenders.TerminatingDigits(value); ok {
+		return g.value(appendDecimal(g.buf, value.FloatString(digits)))
+	}
+	f, _ := value.Float64()
+	return g.FloatValue(f)
+}
+
+func (g *BinaryGenerator) ComplexValue(value complex128) []Appender {
+	var list []Appender
+	list = append(list, g.StartObject()...)
+	list = append(list, g.Key("re")...)
+	list = append(list, g.FloatValue(real(value))...)
+	list = append(list, g.Key("im")...)
+	list = append(list, g.FloatValue(imag(value))...)
+	list = append(list, g.EndObject()...)
+	return list
+}
+
+func (g *BinaryGenerator) StringValue(value string) []Appender {
+	return g.value(appendString(g.buf, typeString, value))
+}
+
+func (g *BinaryGenerator) BytesValue(value []byte) []Appender {
+	return g.value(appendString(g.buf, typeBlob, string(value)))
+}
+
+func (g *BinaryGenerator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *BinaryGenerator) RuneValue(value rune) []Appender {
+	return g.StringValue(string(value))
+}
+
+func (g *BinaryGenerator) TimeValue(value time.Time) []Appender {
+	return g.value(appendTimestamp(g.buf, value))
+}
+
+// symbol returns the symbol ID for name, assigning a new one (to be declared
+// before the current top-level value) if needed.
+func (g *BinaryGenerator) symbol(name string) uint64 {
+	if sid, found := g.symbols[name]; found {
+		return sid
+	}
+	sid := g.nextSID
+	g.nextSID++
+	g.symbols[name] = sid
+	g.newSymbols = append(g.newSymbols, name)
+	return sid
+}
+
+func (g *BinaryGenerator) startContainer(typ byte, next states.State) []Appender {
+	g.sm.ExpectValue()
+	g.stack = append(g.stack, container{typ: typ, start: len(g.buf), annotations: g.annotations})
+	g.annotations = nil
+	g.sm.Push(next)
+	return nil
+}
+
+func (g *BinaryGenerator) endContainer() []Appender {
+	n := len(g.stack) - 1
+	c := g.stack[n]
+	g.stack = g.stack[:n]
+	g.sm.Pop()
+
+	g.insertHeader(c.start, appendHeader(nil, c.typ, uint64(len(g.buf)-c.start)))
+	return g.finish(c.start, c.annotations)
+}
+
+// value accepts buf with a complete scalar appended to it.
+func (g *BinaryGenerator) value(buf []byte) []Appender {
+	g.sm.ExpectValue()
+	start := len(g.buf)
+	g.buf = buf
+	annotations := g.annotations
+	g.annotations = nil
+	return g.finish(start, annotations)
+}
+
+// finish wraps the value at buf[start:] in its annotations and, once the
+// top-level value is complete, writes it out preceded by a symbol table.
+func (g *BinaryGenerator) finish(start int, annotations []uint64) []Appender {
+	if len(annotations) > 0 {
+		var sids []byte
+		for _, sid := range annotations {
+			sids = appendVarUInt(sids, sid)
+		}
+		prefix := appendVarUInt(nil, uint64(len(sids)))
+		prefix = append(prefix, sids...)
+		length := uint64(len(prefix) + len(g.buf) - start)
+		g.insertHeader(start, append(appendHeader(nil, typeAnnotation, length), prefix...))
+	}
+
+	g.sm.Next()
+	if len(g.stack) > 0 {
+		return nil
+	}
+
+	var out []byte
+	out = g.appendSymbolTable(out)
+	out = append(out, g.buf...)
+	g.buf = g.buf[:0]
+	return []Appender{appenders.LiteralBytes(out)}
+}
+
+func (g *BinaryGenerator) insertHeader(start int, header []byte) {
+	end := len(g.buf)
+	g.buf = append(g.buf, header...)
+	copy(g.buf[start+len(header):], g.buf[start:end])
+	copy(g.buf[start:], header)
+}
+
+// appendSymbolTable writes a local symbol table declaring the symbols first
+// used by the current top-level value.  After the first table, each one
+// imports $ion_symbol_table so that it appends to the previous one.
+func (g *BinaryGenerator) appendSymbolTable(out []byte) []byte {
+	if len(g.newSymbols) == 0 {
+		return out
+	}
+
+	var list []byte
+	for _, name := range g.newSymbols {
+		list = appendString(list, typeString, name)
+	}
+	g.newSymbols = g.newSymbols[:0]
+
+	var body []byte
+	if g.declared {
+		body = appendVarUInt(body, sidImports)
+		body = append(body, typeSymbol<<4|1, sidIonSymbolTable)
+	}
+	body = appendVarUInt(body, sidSymbols)
+	body = appendHeader(body, typeList, uint64(len(list)))
+	body = append(body, list...)
+	g.declared = true
+
+	var wrapped []byte
+	wrapped = appendVarUInt(wrapped, 1)
+	wrapped = appendVarUInt(wrapped, sidIonSymbolTable)
+	wrapped = appendHeader(wrapped, typeStruct, uint64(len(body)))
+	wrapped = append(wrapped, body...)
+
+	out = appendHeader(out, typeAnnotation, uint64(len(wrapped)))
+	return append(out, wrapped...)
+}
+
+var (
+	_ emitter.Generator           = (*BinaryGenerator)(nil)
+	_ emitter.StreamGenerator     = (*BinaryGenerator)(nil)
+	_ emitter.AnnotationGenerator = (*BinaryGenerator)(nil)
+)
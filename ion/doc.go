@@ -0,0 +1,2 @@
+// Package ion implements the text and binary forms of Amazon Ion for Emitter.
+package ion
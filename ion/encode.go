@@ -0,0 +1,233 @@
+package ion
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	typeNull       = 0x0
+	typeBool       = 0x1
+	typePosInt     = 0x2
+	typeNegInt     = 0x3
+	typeFloat      = 0x4
+	typeDecimal    = 0x5
+	typeTimestamp  = 0x6
+	typeSymbol     = 0x7
+	typeString     = 0x8
+	typeBlob       = 0xa
+	typeList       = 0xb
+	typeStruct     = 0xd
+	typeAnnotation = 0xe
+
+	lengthVarUInt = 0xe
+	lengthNull    = 0xf
+)
+
+// System symbol IDs from the Ion 1.0 system symbol table.
+const (
+	sidIonSymbolTable = 3
+	sidImports        = 6
+	sidSymbols        = 7
+	firstLocalSID     = 10
+)
+
+var versionMarker = [4]byte{0xe0, 0x01, 0x00, 0xea}
+
+var systemSymbols = [...]string{
+	"$ion",
+	"$ion_1_0",
+	"$ion_symbol_table",
+	"name",
+	"version",
+	"imports",
+	"symbols",
+	"max_id",
+	"$ion_shared_symbol_table",
+}
+
+// appendHeader writes a type descriptor, followed by a VarUInt length if the
+// length does not fit in the low nibble.
+func appendHeader(out []byte, typ byte, length uint64) []byte {
+	if length < lengthVarUInt {
+		return append(out, typ<<4|byte(length))
+	}
+	out = append(out, typ<<4|lengthVarUInt)
+	return appendVarUInt(out, length)
+}
+
+// appendVarUInt writes 7 bits per byte, most significant first, with the
+// high bit set on the last byte.
+func appendVarUInt(out []byte, value uint64) []byte {
+	n := 1
+	for x := value >> 7; x != 0; x >>= 7 {
+		n++
+	}
+	for i := n - 1; i > 0; i-- {
+		out = append(out, byte(value>>(7*i))&0x7f)
+	}
+	return append(out, byte(value)&0x7f|0x80)
+}
+
+// appendVarInt is like appendVarUInt, except that the first byte gives up
+// one bit (0x40) to hold the sign.
+func appendVarInt(out []byte, value int64) []byte {
+	var sign byte
+	magnitude := uint64(value)
+	if value < 0 {
+		sign = 0x40
+		magnitude = -magnitude
+	}
+
+	n := 1
+	for x := magnitude >> 6; x != 0; x >>= 7 {
+		n++
+	}
+	for i := n - 1; i >= 0; i-- {
+		ch := byte(magnitude>>(7*i)) & 0x7f
+		if i == n-1 {
+			ch = ch&0x3f | sign
+		}
+		if i == 0 {
+			ch |= 0x80
+		}
+		out = append(out, ch)
+	}
+	return out
+}
+
+// uintBytes returns the big-endian magnitude with no leading zero bytes.
+func uintBytes(value uint64) []byte {
+	var tmp [8]byte
+	n := 0
+	for x := value; x != 0; x >>= 8 {
+		n++
+	}
+	for i := 0; i < n; i++ {
+		tmp[i] = byte(value >> (8 * (n - 1 - i)))
+	}
+	return tmp[:n]
+}
+
+func appendInt(out []byte, value int64) []byte {
+	if value < 0 {
+		return appendMagnitude(out, typeNegInt, uintBytes(-uint64(value)))
+	}
+	return appendMagnitude(out, typePosInt, uintBytes(uint64(value)))
+}
+
+func appendUint(out []byte, value uint64) []byte {
+	return appendMagnitude(out, typePosInt, uintBytes(value))
+}
+
+func appendBigInt(out []byte, value *big.Int) []byte {
+	if value.Sign() < 0 {
+		return appendMagnitude(out, typeNegInt, new(big.Int).Neg(value).Bytes())
+	}
+	return appendMagnitude(out, typePosInt, value.Bytes())
+}
+
+func appendMagnitude(out []byte, typ byte, magnitude []byte) []byte {
+	out = appendHeader(out, typ, uint64(len(magnitude)))
+	return append(out, magnitude...)
+}
+
+// appendFloat uses the zero-length form for positive zero and the 32-bit
+// form whenever it is exact.
+func appendFloat(out []byte, value float64) []byte {
+	if value == 0 && !math.Signbit(value) {
+		return append(out, typeFloat<<4)
+	}
+	if f32 := float32(value); float64(f32) == value {
+		bits := math.Float32bits(f32)
+		return append(out, typeFloat<<4|4, byte(bits>>24), byte(bits>>16), byte(bits>>8), byte(bits))
+	}
+	bits := math.Float64bits(value)
+	out = append(out, typeFloat<<4|8)
+	for i := 7; i >= 0; i-- {
+		out = append(out, byte(bits>>(8*i)))
+	}
+	return out
+}
+
+// appendDecimal writes a decimal given in the text produced by
+// big.Float.Text or big.Rat.FloatString.
+func appendDecimal(out []byte, str string) []byte {
+	neg := strings.HasPrefix(str, "-")
+	str = strings.TrimPrefix(str, "-")
+
+	var exponent int64
+	if i := strings.IndexByte(str, 'e'); i >= 0 {
+		exponent, _ = strconv.ParseInt(str[i+1:], 10, 64)
+		str = str[:i]
+	}
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		exponent -= int64(len(str) - i - 1)
+		str = str[:i] + str[i+1:]
+	}
+
+	coefficient, _ := new(big.Int).SetString(str, 10)
+	var body []byte
+	body = appendVarInt(body, exponent)
+	body = appendSignedMagnitude(body, neg, coefficient.Bytes())
+	if exponent == 0 && coefficient.Sign() == 0 && !neg {
+		body = body[:0]
+	}
+	out = appendHeader(out, typeDecimal, uint64(len(body)))
+	return append(out, body...)
+}
+
+// appendSignedMagnitude writes an Int field: a big-endian magnitude whose
+// first bit is the sign.  A positive zero is omitted entirely.
+func appendSignedMagnitude(out []byte, neg bool, magnitude []byte) []byte {
+	var sign byte
+	if neg {
+		sign = 0x80
+	}
+	switch {
+	case len(magnitude) == 0 && !neg:
+		return out
+	case len(magnitude) == 0 || magnitude[0]&0x80 != 0:
+		out = append(out, sign)
+		return append(out, magnitude...)
+	default:
+		out = append(out, magnitude[0]|sign)
+		return append(out, magnitude[1:]...)
+	}
+}
+
+// appendTimestamp writes a timestamp with second precision, plus a fraction
+// if there are any nanoseconds.  The fields are in UTC, followed by the
+// original offset.
+func appendTimestamp(out []byte, value time.Time) []byte {
+	_, offset := value.Zone()
+	utc := value.UTC()
+
+	var body []byte
+	body = appendVarInt(body, int64(offset/60))
+	body = appendVarUInt(body, uint64(utc.Year()))
+	body = appendVarUInt(body, uint64(utc.Month()))
+	body = appendVarUInt(body, uint64(utc.Day()))
+	body = appendVarUInt(body, uint64(utc.Hour()))
+	body = appendVarUInt(body, uint64(utc.Minute()))
+	body = appendVarUInt(body, uint64(utc.Second()))
+	if nanos := uint64(utc.Nanosecond()); nanos != 0 {
+		exponent := int64(-9)
+		for nanos%10 == 0 {
+			nanos /= 10
+			exponent++
+		}
+		body = appendVarInt(body, exponent)
+		body = appendSignedMagnitude(body, false, uintBytes(nanos))
+	}
+	out = appendHeader(out, typeTimestamp, uint64(len(body)))
+	return append(out, body...)
+}
+
+func appendString(out []byte, typ byte, value string) []byte {
+	out = appendHeader(out, typ, uint64(len(value)))
+	return append(out, value...)
+}
@@ -0,0 +1,30 @@
+package ion
+
+import (
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/json"
+)
+
+type Text struct {
+	Format         json.Format
+	IndentSize     uint
+	IndentWithTabs bool
+}
+
+func (text Text) NewGenerator() emitter.Generator {
+	g := &TextGenerator{text: text}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = Text{}
+
+type Binary struct{}
+
+func (binary Binary) NewGenerator() emitter.Generator {
+	g := &BinaryGenerator{binary: binary}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = Binary{}
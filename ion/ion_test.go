@@ -0,0 +1,190 @@
+package ion
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/json"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+func TestText(t *testing.T) {
+	type testCase struct {
+		Name    string
+		Input   []Value
+		Factory emitter.GeneratorFactory
+		Expect  string
+	}
+
+	compact := Text{}
+	oneLine := Text{Format: json.OneLine}
+	multiLine := Text{Format: json.MultiLine, IndentSize: 2}
+
+	huge, _ := new(big.Int).SetString("-100000000000000000000", 10)
+	when := time.Date(2000, 1, 2, 3, 4, 5, 500000000, time.FixedZone("", -5*60*60))
+
+	testData := [...]testCase{
+		{Name: "Null", Input: []Value{values.Null{}}, Factory: compact, Expect: "null"},
+		{Name: "Bool", Input: []Value{values.Bool(true)}, Factory: compact, Expect: "true"},
+		{Name: "Int", Input: []Value{values.Int(-42)}, Factory: compact, Expect: "-42"},
+		{Name: "BigInt", Input: []Value{values.BigIntValue{Pointer: huge}}, Factory: compact, Expect: "-100000000000000000000"},
+		{Name: "Float/1.5", Input: []Value{values.Float(1.5)}, Factory: compact, Expect: "1.5e0"},
+		{Name: "Float/1e300", Input: []Value{values.Float(1e300)}, Factory: compact, Expect: "1e+300"},
+		{Name: "Float/NaN", Input: []Value{values.Float(math.NaN())}, Factory: compact, Expect: "nan"},
+		{Name: "Float/-Inf", Input: []Value{values.Float(math.Inf(-1))}, Factory: compact, Expect: "-inf"},
+		{Name: "BigFloat", Input: []Value{values.BigFloatValue{Pointer: big.NewFloat(1.25)}}, Factory: compact, Expect: "1.25"},
+		{Name: "BigFloat/Integer", Input: []Value{values.BigFloatValue{Pointer: big.NewFloat(3)}}, Factory: compact, Expect: "3d0"},
+		{Name: "BigRat/Decimal", Input: []Value{values.BigRatValue{Pointer: big.NewRat(-1, 8)}}, Factory: compact, Expect: "-0.125"},
+		{Name: "BigRat/Repeating", Input: []Value{values.BigRatValue{Pointer: big.NewRat(1, 3)}}, Factory: compact, Expect: "0.3333333333333333e0"},
+		{Name: "String", Input: []Value{values.String("a\"b\\c\n\x01é")}, Factory: compact, Expect: "\"a\\\"b\\\\c\\n\\x01é\""},
+		{Name: "Bytes", Input: []Value{values.Bytes("hello")}, Factory: compact, Expect: "{{aGVsbG8=}}"},
+		{Name: "Time", Input: []Value{values.Time(when)}, Factory: compact, Expect: "2000-01-02T03:04:05.5-05:00"},
+		{Name: "Complex", Input: []Value{values.Complex(complex(1, -2))}, Factory: oneLine, Expect: "{re: 1e0, im: -2e0}\n"},
+		{
+			Name: "Object",
+			Input: []Value{values.Object{
+				{Key: "name", Value: values.String("x")},
+				{Key: "two words", Value: values.Array{values.Int(1), values.Int(2)}},
+				{Key: "null", Value: values.Object(nil)},
+				{Key: "$1", Value: values.Array(nil)},
+			}},
+			Factory: oneLine,
+			Expect:  "{name: \"x\", 'two words': [1, 2], 'null': {}, '$1': []}\n",
+		},
+		{
+			Name: "Object/MultiLine",
+			Input: []Value{values.Object{
+				{Key: "a", Value: values.Array{values.Int(1), values.Bool(false)}},
+				{Key: "b", Value: values.Null{}},
+			}},
+			Factory: multiLine,
+			Expect:  "{\n  a: [\n    1,\n    false\n  ],\n  b: null\n}\n",
+		},
+		{
+			Name: "Annotated",
+			Input: []Value{values.Array{
+				values.Annotated{Annotations: []string{"degrees"}, Value: values.Int(90)},
+				values.Annotated{Annotations: []string{"a", "it's"}, Value: values.Object{{Key: "k", Value: values.Int(1)}}},
+			}},
+			Factory: oneLine,
+			Expect:  "[degrees::90, a::'it\\'s'::{k: 1}]\n",
+		},
+		{Name: "Stream/Compact", Input: []Value{values.Int(1), values.Int(2)}, Factory: compact, Expect: "1 2"},
+		{Name: "Stream/OneLine", Input: []Value{values.Object{{Key: "a", Value: values.Int(1)}}, values.Int(2)}, Factory: oneLine, Expect: "{a: 1}\n2\n"},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, row.Factory.NewGenerator())
+			for index, input := range row.Input {
+				if index > 0 {
+					e.NextDocument()
+				}
+				input.EmitTo(&e)
+			}
+			if err := e.Close(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if actual := buf.String(); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestBinary(t *testing.T) {
+	type testCase struct {
+		Name   string
+		Input  []Value
+		Expect string
+	}
+
+	const bvm = "e00100ea"
+
+	huge, _ := new(big.Int).SetString("-100000000000000000000", 10)
+	when := time.Date(2000, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testData := [...]testCase{
+		{Name: "Null", Input: []Value{values.Null{}}, Expect: bvm + "0f"},
+		{Name: "Bool", Input: []Value{values.Bool(true)}, Expect: bvm + "11"},
+		{Name: "Int/0", Input: []Value{values.Int(0)}, Expect: bvm + "20"},
+		{Name: "Int/1", Input: []Value{values.Int(1)}, Expect: bvm + "2101"},
+		{Name: "Int/-1", Input: []Value{values.Int(-1)}, Expect: bvm + "3101"},
+		{Name: "Int/Min", Input: []Value{values.Int(math.MinInt64)}, Expect: bvm + "388000000000000000"},
+		{Name: "Uint/Max", Input: []Value{values.Uint(math.MaxUint64)}, Expect: bvm + "28ffffffffffffffff"},
+		{Name: "BigInt", Input: []Value{values.BigIntValue{Pointer: huge}}, Expect: bvm + "39056bc75e2d63100000"},
+		{Name: "Float/0", Input: []Value{values.Float(0)}, Expect: bvm + "40"},
+		{Name: "Float/-0", Input: []Value{values.Float(math.Copysign(0, -1))}, Expect: bvm + "4480000000"},
+		{Name: "Float/1.5", Input: []Value{values.Float(1.5)}, Expect: bvm + "443fc00000"},
+		{Name: "Float/1.1", Input: []Value{values.Float(1.1)}, Expect: bvm + "483ff199999999999a"},
+		{Name: "Float/Inf", Input: []Value{values.Float(math.Inf(1))}, Expect: bvm + "447f800000"},
+		{Name: "Decimal/0.125", Input: []Value{values.BigRatValue{Pointer: big.NewRat(1, 8)}}, Expect: bvm + "52c37d"},
+		{Name: "Decimal/-0.5", Input: []Value{values.BigRatValue{Pointer: big.NewRat(-1, 2)}}, Expect: bvm + "52c185"},
+		{Name: "Decimal/2", Input: []Value{values.BigRatValue{Pointer: big.NewRat(2, 1)}}, Expect: bvm + "528002"},
+		{Name: "Decimal/0", Input: []Value{values.BigRatValue{Pointer: new(big.Rat)}}, Expect: bvm + "50"},
+		{Name: "Decimal/BigFloat", Input: []Value{values.BigFloatValue{Pointer: big.NewFloat(1e100)}}, Expect: bvm + "5300e401"},
+		{Name: "String", Input: []Value{values.String("abc")}, Expect: bvm + "83616263"},
+		{Name: "Blob", Input: []Value{values.Bytes{1, 2, 3}}, Expect: bvm + "a3010203"},
+		{Name: "Timestamp", Input: []Value{values.Time(when)}, Expect: bvm + "68800fd081828384" + "85"},
+		{Name: "Timestamp/Fraction", Input: []Value{values.Time(when.Add(250 * time.Millisecond))}, Expect: bvm + "6a800fd081828384" + "85c219"},
+		{Name: "List", Input: []Value{values.Array{values.Int(1), values.Array(nil)}}, Expect: bvm + "b32101b0"},
+		{
+			Name:   "Struct",
+			Input:  []Value{values.Object{{Key: "a", Value: values.Int(1)}}},
+			Expect: bvm + "e78183d487b28161" + "d38a2101",
+		},
+		{
+			Name:   "Struct/SystemSymbol",
+			Input:  []Value{values.Object{{Key: "name", Value: values.Int(1)}}},
+			Expect: bvm + "d3842101",
+		},
+		{
+			Name:   "Annotated",
+			Input:  []Value{values.Annotated{Annotations: []string{"x"}, Value: values.Int(1)}},
+			Expect: bvm + "e78183d487b28178" + "e4818a2101",
+		},
+		{
+			Name: "Stream",
+			Input: []Value{
+				values.Object{{Key: "a", Value: values.Int(1)}},
+				values.Object{{Key: "a", Value: values.Int(2)}, {Key: "b", Value: values.Int(3)}},
+			},
+			Expect: bvm + "e78183d487b28161" + "d38a2101" + "ea8183d7867103" + "87b28162" + "d68a21028b2103",
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, Binary{}.NewGenerator())
+			for index, input := range row.Input {
+				if index > 0 {
+					e.NextDocument()
+				}
+				input.EmitTo(&e)
+			}
+			if err := e.Close(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if actual := hex.EncodeToString(buf.Bytes()); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %s\n\tactual: %s", row.Expect, actual)
+			}
+		})
+	}
+}
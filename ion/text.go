@@ -0,0 +1,265 @@
+package ion
+
+import (
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/json"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+type TextGenerator struct {
+	text        Text
+	sm          states.Machine
+	annotations []string
+}
+
+func (g *TextGenerator) Reset() {
+	g.sm.Reset()
+	g.annotations = g.annotations[:0]
+}
+
+func (g *TextGenerator) Factory() emitter.GeneratorFactory {
+	return g.text
+}
+
+func (g *TextGenerator) Begin() []Appender {
+	g.sm.ExpectRoot()
+	return nil
+}
+
+func (g *TextGenerator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+
+	var b appenders.Builder
+	g.text.Format.LineFeed(&b)
+	return b.Build()
+}
+
+func (g *TextGenerator) NextDocument() []Appender {
+	g.sm.NextDocument()
+	if g.text.Format == json.Compact {
+		return []Appender{appenders.LiteralString(" ")}
+	}
+	return []Appender{appenders.LiteralString("\n")}
+}
+
+func (g *TextGenerator) StartObject() []Appender {
+	return g.startContainer('{', states.ObjectFirstKey)
+}
+
+func (g *TextGenerator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	return g.endContainer('}', states.ObjectNextKey)
+}
+
+func (g *TextGenerator) StartArray() []Appender {
+	return g.startContainer('[', states.ArrayFirstValue)
+}
+
+func (g *TextGenerator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	return g.endContainer(']', states.ArrayNextValue)
+}
+
+func (g *TextGenerator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+
+	var b appenders.Builder
+	if g.sm.State.In(states.ObjectFirstKey) {
+		g.indent(&b)
+	}
+	if g.sm.State.In(states.ObjectNextKey) {
+		b.AddByte(',')
+		g.indentOrSpace(&b)
+	}
+	b.Add(SymbolAppender{Value: key})
+	b.AddByte(':')
+	g.text.Format.Space(&b)
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *TextGenerator) Annotate(annotations []string) []Appender {
+	g.sm.ExpectValue()
+	g.annotations = append(g.annotations, annotations...)
+	return nil
+}
+
+func (g *TextGenerator) NullValue() []Appender {
+	return g.literal(`null`)
+}
+
+func (g *TextGenerator) BoolValue(value bool) []Appender {
+	if value {
+		return g.literal(`true`)
+	}
+	return g.literal(`false`)
+}
+
+func (g *TextGenerator) IntValue(value int64) []Appender {
+	return g.value(appenders.IntText(value))
+}
+
+func (g *TextGenerator) UintValue(value uint64) []Appender {
+	return g.value(appenders.UintText(value))
+}
+
+func (g *TextGenerator) BigIntValue(value *big.Int) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	return g.value(appenders.BigIntText{Pointer: value})
+}
+
+func (g *TextGenerator) NaNValue() []Appender {
+	return g.literal(`nan`)
+}
+
+func (g *TextGenerator) InfValue(isNeg bool) []Appender {
+	if isNeg {
+		return g.literal(`-inf`)
+	}
+	return g.literal(`+inf`)
+}
+
+func (g *TextGenerator) FloatValue(value float64) []Appender {
+	return g.value(FloatAppender(value))
+}
+
+func (g *TextGenerator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if value.IsInf() {
+		return g.InfValue(value.Signbit())
+	}
+	return g.literal(decimalText(value.Text('g', -1)))
+}
+
+func (g *TextGenerator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if digits, ok := appenders.TerminatingDigits(value); ok {
+		return g.literal(decimalText(value.FloatString(digits)))
+	}
+	f, _ := value.Float64()
+	return g.FloatValue(f)
+}
+
+func (g *TextGenerator) ComplexValue(value complex128) []Appender {
+	var list []Appender
+	list = append(list, g.StartObject()...)
+	list = append(list, g.Key("re")...)
+	list = append(list, g.FloatValue(real(value))...)
+	list = append(list, g.Key("im")...)
+	list = append(list, g.FloatValue(imag(value))...)
+	list = append(list, g.EndObject()...)
+	return list
+}
+
+func (g *TextGenerator) StringValue(value string) []Appender {
+	return g.value(StringAppender{Value: value})
+}
+
+func (g *TextGenerator) BytesValue(value []byte) []Appender {
+	return g.value(BlobAppender{Value: value})
+}
+
+func (g *TextGenerator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *TextGenerator) RuneValue(value rune) []Appender {
+	return g.StringValue(string(value))
+}
+
+func (g *TextGenerator) TimeValue(value time.Time) []Appender {
+	return g.literal(value.Format(time.RFC3339Nano))
+}
+
+// decimalText converts a number formatted by package strconv or math/big
+// into an Ion decimal, which uses 'd' for its exponent and needs one even
+// when there is no decimal point.
+func decimalText(str string) string {
+	if strings.IndexByte(str, 'e') >= 0 {
+		return strings.Replace(str, "e", "d", 1)
+	}
+	if strings.IndexByte(str, '.') < 0 {
+		return str + "d0"
+	}
+	return str
+}
+
+func (g *TextGenerator) startContainer(open byte, next states.State) []Appender {
+	g.sm.ExpectValue()
+
+	var b appenders.Builder
+	g.separator(&b)
+	g.sm.Push(next)
+	b.AddByte(open)
+	return b.Build()
+}
+
+func (g *TextGenerator) endContainer(close byte, nonEmpty states.State) []Appender {
+	needIndent := g.sm.State.In(nonEmpty)
+	g.sm.Pop()
+
+	var b appenders.Builder
+	if needIndent {
+		g.indent(&b)
+	}
+	b.AddByte(close)
+	g.sm.Next()
+	return b.Build()
+}
+
+// separator writes whatever precedes the next value: a comma and indentation
+// inside a list, and then any pending annotations.
+func (g *TextGenerator) separator(b *appenders.Builder) {
+	if g.sm.State.In(states.ArrayFirstValue) {
+		g.indent(b)
+	}
+	if g.sm.State.In(states.ArrayNextValue) {
+		b.AddByte(',')
+		g.indentOrSpace(b)
+	}
+	for _, annotation := range g.annotations {
+		b.Add(SymbolAppender{Value: annotation})
+		b.AddString("::")
+	}
+	g.annotations = g.annotations[:0]
+}
+
+func (g *TextGenerator) value(a Appender) []Appender {
+	g.sm.ExpectValue()
+
+	var b appenders.Builder
+	g.separator(&b)
+	b.Add(a)
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *TextGenerator) literal(str string) []Appender {
+	return g.value(appenders.LiteralString(str))
+}
+
+func (g *TextGenerator) indent(b *appenders.Builder) {
+	g.text.Format.Indent(b, g.text.IndentWithTabs, g.text.IndentSize, g.sm.Depth())
+}
+
+func (g *TextGenerator) indentOrSpace(b *appenders.Builder) {
+	g.text.Format.IndentOrSpace(b, g.text.IndentWithTabs, g.text.IndentSize, g.sm.Depth())
+}
+
+var (
+	_ emitter.Generator           = (*TextGenerator)(nil)
+	_ emitter.StreamGenerator     = (*TextGenerator)(nil)
+	_ emitter.AnnotationGenerator = (*TextGenerator)(nil)
+)
@@ -0,0 +1,112 @@
+package jcs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chronos-tachyon/go-emitter/appenders"
+)
+
+type Appender = appenders.Appender
+
+type StringAppender struct {
+	Value string
+}
+
+func (a StringAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a StringAppender) Append(out []byte) []byte {
+	const hexDigits = "0123456789abcdef"
+
+	out = append(out, '"')
+	for i := 0; i < len(a.Value); i++ {
+		ch := a.Value[i]
+		switch {
+		case ch == '"':
+			out = append(out, '\\', '"')
+		case ch == '\\':
+			out = append(out, '\\', '\\')
+		case ch == '\b':
+			out = append(out, '\\', 'b')
+		case ch == '\t':
+			out = append(out, '\\', 't')
+		case ch == '\n':
+			out = append(out, '\\', 'n')
+		case ch == '\f':
+			out = append(out, '\\', 'f')
+		case ch == '\r':
+			out = append(out, '\\', 'r')
+		case ch < 0x20:
+			out = append(out, '\\', 'u', '0', '0', hexDigits[ch>>4], hexDigits[ch&15])
+		default:
+			out = append(out, ch)
+		}
+	}
+	return append(out, '"')
+}
+
+var (
+	_ fmt.Stringer = StringAppender{}
+	_ Appender     = StringAppender{}
+)
+
+// NumberAppender formats a finite float64 using the ECMAScript
+// Number.prototype.toString algorithm, as required by RFC 8785.
+type NumberAppender float64
+
+func (a NumberAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a NumberAppender) Append(out []byte) []byte {
+	value := float64(a)
+	if value == 0 {
+		return append(out, '0')
+	}
+	if value < 0 {
+		out = append(out, '-')
+		value = -value
+	}
+
+	// Shortest round-trip digits, as "d.ddde±x".
+	str := strconv.FormatFloat(value, 'e', -1, 64)
+	mantissa, exp, _ := strings.Cut(str, "e")
+	digits := strings.Replace(mantissa, ".", "", 1)
+	x, _ := strconv.Atoi(exp)
+	k := len(digits)
+	n := x + 1
+
+	switch {
+	case k <= n && n <= 21:
+		out = append(out, digits...)
+		out = append(out, strings.Repeat("0", n-k)...)
+	case 0 < n && n <= 21:
+		out = append(out, digits[:n]...)
+		out = append(out, '.')
+		out = append(out, digits[n:]...)
+	case -6 < n && n <= 0:
+		out = append(out, '0', '.')
+		out = append(out, strings.Repeat("0", -n)...)
+		out = append(out, digits...)
+	default:
+		out = append(out, digits[0])
+		if k > 1 {
+			out = append(out, '.')
+			out = append(out, digits[1:]...)
+		}
+		out = append(out, 'e')
+		if n-1 >= 0 {
+			out = append(out, '+')
+		}
+		out = strconv.AppendInt(out, int64(n-1), 10)
+	}
+	return out
+}
+
+var (
+	_ fmt.Stringer = NumberAppender(0)
+	_ Appender     = NumberAppender(0)
+)
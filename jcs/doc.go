@@ -0,0 +1,2 @@
+// Package jcs implements the JSON Canonicalization Scheme (RFC 8785) for Emitter.
+package jcs
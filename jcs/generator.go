@@ -0,0 +1,286 @@
+package jcs
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+type entry struct {
+	key   string
+	start int
+	end   int
+}
+
+type frame struct {
+	isObject bool
+	count    uint
+	key      string
+	buf      []byte
+	entries  []entry
+}
+
+type Generator struct {
+	jcs    JCS
+	sm     states.Machine
+	frames []frame
+}
+
+func (g *Generator) Reset() {
+	g.sm.Reset()
+	g.frames = g.frames[:0]
+}
+
+func (g *Generator) Factory() emitter.GeneratorFactory {
+	return g.jcs
+}
+
+func (g *Generator) Begin() []Appender {
+	g.sm.ExpectRoot()
+	return nil
+}
+
+func (g *Generator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+	return nil
+}
+
+func (g *Generator) StartObject() []Appender {
+	g.sm.ExpectValue()
+	g.sm.Push(states.ObjectFirstKey)
+	g.frames = append(g.frames, frame{isObject: true})
+	return nil
+}
+
+func (g *Generator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	f := g.pop()
+
+	sort.SliceStable(f.entries, func(i, j int) bool {
+		return lessUTF16(f.entries[i].key, f.entries[j].key)
+	})
+
+	out := make([]byte, 0, len(f.buf)+2)
+	out = append(out, '{')
+	for index, e := range f.entries {
+		if index > 0 {
+			if e.key == f.entries[index-1].key {
+				g.sm.Next()
+				return g.fail(fmt.Errorf("JCS forbids duplicate object key %q", e.key))
+			}
+			out = append(out, ',')
+		}
+		out = StringAppender{Value: e.key}.Append(out)
+		out = append(out, ':')
+		out = append(out, f.buf[e.start:e.end]...)
+	}
+	out = append(out, '}')
+	return g.write(out)
+}
+
+func (g *Generator) StartArray() []Appender {
+	g.sm.ExpectValue()
+	g.sm.Push(states.ArrayFirstValue)
+	g.frames = append(g.frames, frame{buf: []byte{'['}})
+	return nil
+}
+
+func (g *Generator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	f := g.pop()
+	return g.write(append(f.buf, ']'))
+}
+
+func (g *Generator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+	g.frames[len(g.frames)-1].key = key
+	g.sm.Next()
+	if !utf8.ValidString(key) {
+		return g.fail(fmt.Errorf("JCS requires valid UTF-8, but key %q is not", key))
+	}
+	return nil
+}
+
+func (g *Generator) NullValue() []Appender {
+	return g.value(appenders.LiteralString(`null`))
+}
+
+func (g *Generator) BoolValue(value bool) []Appender {
+	if value {
+		return g.value(appenders.LiteralString(`true`))
+	}
+	return g.value(appenders.LiteralString(`false`))
+}
+
+func (g *Generator) IntValue(value int64) []Appender {
+	return g.BigIntValue(big.NewInt(value))
+}
+
+func (g *Generator) UintValue(value uint64) []Appender {
+	return g.BigIntValue(new(big.Int).SetUint64(value))
+}
+
+func (g *Generator) BigIntValue(value *big.Int) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	f, acc := new(big.Float).SetInt(value).Float64()
+	if acc != big.Exact || math.IsInf(f, 0) {
+		return g.inexact(value)
+	}
+	return g.FloatValue(f)
+}
+
+func (g *Generator) NaNValue() []Appender {
+	return g.invalid(fmt.Errorf("JCS does not support NaN"))
+}
+
+func (g *Generator) InfValue(isNeg bool) []Appender {
+	return g.invalid(fmt.Errorf("JCS does not support infinities"))
+}
+
+func (g *Generator) FloatValue(value float64) []Appender {
+	switch {
+	case math.IsNaN(value):
+		return g.NaNValue()
+	case math.IsInf(value, 0):
+		return g.InfValue(value < 0)
+	default:
+		return g.value(NumberAppender(value))
+	}
+}
+
+func (g *Generator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if value.IsInf() {
+		return g.InfValue(value.Signbit())
+	}
+	f, acc := value.Float64()
+	if acc != big.Exact || math.IsInf(f, 0) {
+		return g.inexact(value)
+	}
+	return g.FloatValue(f)
+}
+
+func (g *Generator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	f, exact := value.Float64()
+	if !exact {
+		return g.inexact(value)
+	}
+	return g.FloatValue(f)
+}
+
+func (g *Generator) ComplexValue(value complex128) []Appender {
+	var list []Appender
+	list = append(list, g.StartObject()...)
+	list = append(list, g.Key("re")...)
+	list = append(list, g.FloatValue(real(value))...)
+	list = append(list, g.Key("im")...)
+	list = append(list, g.FloatValue(imag(value))...)
+	list = append(list, g.EndObject()...)
+	return list
+}
+
+func (g *Generator) StringValue(value string) []Appender {
+	if !utf8.ValidString(value) {
+		return g.invalid(fmt.Errorf("JCS requires valid UTF-8, but string %q is not", value))
+	}
+	return g.value(StringAppender{Value: value})
+}
+
+func (g *Generator) BytesValue(value []byte) []Appender {
+	return g.StringValue(base64.StdEncoding.EncodeToString(value))
+}
+
+func (g *Generator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *Generator) RuneValue(value rune) []Appender {
+	return g.StringValue(string(value))
+}
+
+func (g *Generator) TimeValue(value time.Time) []Appender {
+	return g.StringValue(value.Format(time.RFC3339Nano))
+}
+
+func (g *Generator) inexact(value any) []Appender {
+	return g.invalid(fmt.Errorf("JCS cannot represent %v exactly as an IEEE 754 double", value))
+}
+
+func (g *Generator) invalid(err error) []Appender {
+	g.sm.ExpectValue()
+	g.sm.Next()
+	return g.fail(err)
+}
+
+func (g *Generator) value(a Appender) []Appender {
+	g.sm.ExpectValue()
+	return g.write(a.Append(nil))
+}
+
+// write adds an encoded value to the enclosing container, or emits it
+// directly if it is the root value.
+func (g *Generator) write(data []byte) []Appender {
+	defer g.sm.Next()
+
+	n := len(g.frames)
+	if n <= 0 {
+		return []Appender{appenders.LiteralBytes(data)}
+	}
+
+	f := &g.frames[n-1]
+	if f.isObject {
+		start := len(f.buf)
+		f.buf = append(f.buf, data...)
+		f.entries = append(f.entries, entry{key: f.key, start: start, end: len(f.buf)})
+		return nil
+	}
+
+	if f.count > 0 {
+		f.buf = append(f.buf, ',')
+	}
+	f.buf = append(f.buf, data...)
+	f.count++
+	return nil
+}
+
+func (g *Generator) pop() frame {
+	n := len(g.frames) - 1
+	f := g.frames[n]
+	g.frames = g.frames[:n]
+	g.sm.Pop()
+	return f
+}
+
+func (g *Generator) fail(err error) []Appender {
+	return []Appender{appenders.Error{Err: err}}
+}
+
+func lessUTF16(a string, b string) bool {
+	x := utf16.Encode([]rune(a))
+	y := utf16.Encode([]rune(b))
+	for i := 0; i < len(x) && i < len(y); i++ {
+		if x[i] != y[i] {
+			return x[i] < y[i]
+		}
+	}
+	return len(x) < len(y)
+}
+
+var _ emitter.Generator = (*Generator)(nil)
@@ -0,0 +1,15 @@
+package jcs
+
+import (
+	"github.com/chronos-tachyon/go-emitter"
+)
+
+type JCS struct{}
+
+func (jcs JCS) NewGenerator() emitter.Generator {
+	g := &Generator{jcs: jcs}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = JCS{}
@@ -0,0 +1,165 @@
+package jcs
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+func TestJCS(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Input     Value
+		Expect    string
+		ExpectErr error
+	}
+
+	testData := [...]testCase{
+		// RFC 8785, Section 3.2.2
+		{
+			Name: "RFC8785/Example",
+			Input: values.Object{
+				{Key: "numbers", Value: values.Array{
+					values.Float(333333333.33333329),
+					values.Float(1e30),
+					values.Float(4.50),
+					values.Float(2e-3),
+					values.Float(0.000000000000000000000000001),
+				}},
+				{Key: "string", Value: values.String("\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"/")},
+				{Key: "literals", Value: values.Array{values.Null{}, values.Bool(true), values.Bool(false)}},
+			},
+			Expect: `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+		},
+		// RFC 8785, Section 3.2.3
+		{
+			Name: "RFC8785/Sorting",
+			Input: values.Object{
+				{Key: "\u20ac", Value: values.String("Euro Sign")},
+				{Key: "\r", Value: values.String("Carriage Return")},
+				{Key: "\ufb33", Value: values.String("Hebrew Letter Dalet With Dagesh")},
+				{Key: "1", Value: values.String("One")},
+				{Key: "\U0001f600", Value: values.String("Emoji: Grinning Face")},
+				{Key: "\u0080", Value: values.String("Control")},
+				{Key: "\u00f6", Value: values.String("Latin Small Letter O With Diaeresis")},
+			},
+			Expect: "{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"\u00f6\":\"Latin Small Letter O With Diaeresis\",\"\u20ac\":\"Euro Sign\",\"\U0001f600\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}",
+		},
+		{
+			Name:   "Nested",
+			Input:  values.Array{values.Object{{Key: "b", Value: values.Array(nil)}, {Key: "a", Value: values.Object(nil)}}, values.Int(-1)},
+			Expect: `[{"a":{},"b":[]},-1]`,
+		},
+		{
+			Name: "NestedKeys",
+			Input: values.Object{
+				{Key: "z", Value: values.Int(0)},
+				{Key: "a", Value: values.Object{{Key: "b", Value: values.Int(1)}}},
+			},
+			Expect: `{"a":{"b":1},"z":0}`,
+		},
+		{
+			Name: "NestedSiblings",
+			Input: values.Object{
+				{Key: "a", Value: values.Object{{Key: "x", Value: values.Int(1)}}},
+				{Key: "b", Value: values.Object{{Key: "x", Value: values.Int(1)}}},
+			},
+			Expect: `{"a":{"x":1},"b":{"x":1}}`,
+		},
+		{
+			Name:   "ExactInt",
+			Input:  values.Int(1 << 60),
+			Expect: `1152921504606847000`,
+		},
+		{
+			Name:      "InexactInt",
+			Input:     values.Int(1<<53 + 1),
+			ExpectErr: fmt.Errorf("JCS cannot represent %v exactly as an IEEE 754 double", big.NewInt(1<<53+1)),
+		},
+		{
+			Name:      "NaN",
+			Input:     values.Float(math.NaN()),
+			ExpectErr: fmt.Errorf("JCS does not support NaN"),
+		},
+		{
+			Name:      "Inf",
+			Input:     values.Array{values.Float(math.Inf(-1))},
+			ExpectErr: fmt.Errorf("JCS does not support infinities"),
+		},
+		{
+			Name:      "Duplicate",
+			Input:     values.Object{{Key: "a", Value: values.Int(1)}, {Key: "a", Value: values.Int(2)}},
+			ExpectErr: fmt.Errorf("JCS forbids duplicate object key %q", "a"),
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, JCS{}.NewGenerator())
+			row.Input.EmitTo(&e)
+			err := e.Close()
+
+			if !reflect.DeepEqual(err, row.ExpectErr) {
+				t.Errorf("wrong error:\n\texpect: %#v\n\tactual: %#v", row.ExpectErr, err)
+				return
+			}
+
+			if actual := buf.String(); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
+
+// RFC 8785, Appendix B
+func TestNumberAppender(t *testing.T) {
+	type testCase struct {
+		Bits   uint64
+		Expect string
+	}
+
+	testData := [...]testCase{
+		{0x0000000000000000, "0"},
+		{0x8000000000000000, "0"},
+		{0x0000000000000001, "5e-324"},
+		{0x8000000000000001, "-5e-324"},
+		{0x7fefffffffffffff, "1.7976931348623157e+308"},
+		{0xffefffffffffffff, "-1.7976931348623157e+308"},
+		{0x4340000000000000, "9007199254740992"},
+		{0xc340000000000000, "-9007199254740992"},
+		{0x4430000000000000, "295147905179352830000"},
+		{0x44b52d02c7e14af5, "9.999999999999997e+22"},
+		{0x44b52d02c7e14af6, "1e+23"},
+		{0x44b52d02c7e14af7, "1.0000000000000001e+23"},
+		{0x444b1ae4d6e2ef4e, "999999999999999700000"},
+		{0x444b1ae4d6e2ef4f, "999999999999999900000"},
+		{0x444b1ae4d6e2ef50, "1e+21"},
+		{0x3eb0c6f7a0b5ed8c, "9.999999999999997e-7"},
+		{0x3eb0c6f7a0b5ed8d, "0.000001"},
+		{0x41b3de4355555553, "333333333.3333332"},
+		{0x41b3de4355555554, "333333333.33333325"},
+		{0x41b3de4355555555, "333333333.3333333"},
+		{0x41b3de4355555556, "333333333.3333334"},
+		{0x41b3de4355555557, "333333333.33333343"},
+		{0xbecbf647612f3696, "-0.0000033333333333333333"},
+		{0x43143ff3c1cb0959, "1424953923781206.2"},
+	}
+
+	for _, row := range testData {
+		value := math.Float64frombits(row.Bits)
+		if actual := NumberAppender(value).String(); actual != row.Expect {
+			t.Errorf("%016x: expect %q, actual %q", row.Bits, row.Expect, actual)
+		}
+	}
+}
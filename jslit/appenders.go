@@ -0,0 +1,101 @@
+package jslit
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/json"
+	"github.com/chronos-tachyon/go-emitter/json5"
+)
+
+type Appender = appenders.Appender
+
+// StringAppender writes a JavaScript string literal.  JSON escaping is used,
+// plus escapes for U+2028 and U+2029, which older engines reject in source.
+type StringAppender struct {
+	Value      string
+	EscapeHTML bool
+}
+
+func (a StringAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a StringAppender) Append(out []byte) []byte {
+	quoted := json.StringAppender{Value: a.Value, EscapeHTML: a.EscapeHTML}.Append(nil)
+	for i := 0; i < len(quoted); i++ {
+		// U+2028 and U+2029 are encoded as E2 80 A8 and E2 80 A9.
+		if quoted[i] == 0xe2 && i+2 < len(quoted) && quoted[i+1] == 0x80 {
+			switch quoted[i+2] {
+			case 0xa8:
+				out = append(out, `\u2028`...)
+				i += 2
+				continue
+			case 0xa9:
+				out = append(out, `\u2029`...)
+				i += 2
+				continue
+			}
+		}
+		out = append(out, quoted[i])
+	}
+	return out
+}
+
+var (
+	_ fmt.Stringer = StringAppender{}
+	_ Appender     = StringAppender{}
+)
+
+type KeyAppender struct {
+	Value      string
+	EscapeHTML bool
+}
+
+func (a KeyAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a KeyAppender) Append(out []byte) []byte {
+	if a.Value == "__proto__" {
+		// In an object literal, both __proto__ and "__proto__" set the
+		// prototype instead of defining a property.
+		out = append(out, '[')
+		out = StringAppender(a).Append(out)
+		return append(out, ']')
+	}
+	if json5.IsIdentifier(a.Value) {
+		return append(out, a.Value...)
+	}
+	return StringAppender(a).Append(out)
+}
+
+var (
+	_ fmt.Stringer = KeyAppender{}
+	_ Appender     = KeyAppender{}
+)
+
+type BytesAppender struct {
+	Value []byte
+}
+
+func (a BytesAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a BytesAppender) Append(out []byte) []byte {
+	out = append(out, "Uint8Array.from(["...)
+	for index, ch := range a.Value {
+		if index > 0 {
+			out = append(out, ", "...)
+		}
+		out = strconv.AppendUint(out, uint64(ch), 10)
+	}
+	return append(out, "])"...)
+}
+
+var (
+	_ fmt.Stringer = BytesAppender{}
+	_ Appender     = BytesAppender{}
+)
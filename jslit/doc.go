@@ -0,0 +1,2 @@
+// Package jslit implements JavaScript expressions for Emitter.
+package jslit
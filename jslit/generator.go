@@ -0,0 +1,241 @@
+package jslit
+
+import (
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+// maxSafeInteger is Number.MAX_SAFE_INTEGER; integers beyond it are written
+// as BigInt literals so that no precision is lost.
+const maxSafeInteger = 1<<53 - 1
+
+type Generator struct {
+	javascript JavaScript
+	sm         states.Machine
+}
+
+func (g *Generator) Reset() {
+	g.sm.Reset()
+}
+
+func (g *Generator) Factory() emitter.GeneratorFactory {
+	return g.javascript
+}
+
+func (g *Generator) Begin() []Appender {
+	g.sm.ExpectRoot()
+	return nil
+}
+
+func (g *Generator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+
+	var b appenders.Builder
+	g.javascript.Format.LineFeed(&b)
+	return b.Build()
+}
+
+func (g *Generator) StartObject() []Appender {
+	return g.startContainer('{', states.ObjectFirstKey)
+}
+
+func (g *Generator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	return g.endContainer('}', states.ObjectNextKey)
+}
+
+func (g *Generator) StartArray() []Appender {
+	return g.startContainer('[', states.ArrayFirstValue)
+}
+
+func (g *Generator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	return g.endContainer(']', states.ArrayNextValue)
+}
+
+func (g *Generator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+
+	var b appenders.Builder
+	if g.sm.State.In(states.ObjectFirstKey) {
+		g.indent(&b)
+	}
+	if g.sm.State.In(states.ObjectNextKey) {
+		b.AddByte(',')
+		g.indentOrSpace(&b)
+	}
+	b.Add(KeyAppender{Value: key, EscapeHTML: g.javascript.EscapeHTML})
+	b.AddByte(':')
+	g.javascript.Format.Space(&b)
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) NullValue() []Appender {
+	return g.literal(`null`)
+}
+
+func (g *Generator) BoolValue(value bool) []Appender {
+	if value {
+		return g.literal(`true`)
+	}
+	return g.literal(`false`)
+}
+
+func (g *Generator) IntValue(value int64) []Appender {
+	if value < -maxSafeInteger || value > maxSafeInteger {
+		return g.BigIntValue(big.NewInt(value))
+	}
+	return g.value(appenders.IntText(value))
+}
+
+func (g *Generator) UintValue(value uint64) []Appender {
+	if value > maxSafeInteger {
+		return g.BigIntValue(new(big.Int).SetUint64(value))
+	}
+	return g.value(appenders.UintText(value))
+}
+
+func (g *Generator) BigIntValue(value *big.Int) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	return g.literal(value.String() + "n")
+}
+
+func (g *Generator) NaNValue() []Appender {
+	return g.literal(`NaN`)
+}
+
+func (g *Generator) InfValue(isNeg bool) []Appender {
+	if isNeg {
+		return g.literal(`-Infinity`)
+	}
+	return g.literal(`Infinity`)
+}
+
+func (g *Generator) FloatValue(value float64) []Appender {
+	switch {
+	case math.IsNaN(value):
+		return g.NaNValue()
+	case math.IsInf(value, 0):
+		return g.InfValue(value < 0)
+	default:
+		return g.value(appenders.FloatText(value))
+	}
+}
+
+func (g *Generator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if value.IsInf() {
+		return g.InfValue(value.Signbit())
+	}
+	return g.value(appenders.BigFloatText{Pointer: value})
+}
+
+func (g *Generator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if _, ok := appenders.TerminatingDigits(value); ok {
+		return g.value(appenders.BigRatDecimalText{Pointer: value})
+	}
+	return g.literal(value.Num().String() + " / " + value.Denom().String())
+}
+
+func (g *Generator) ComplexValue(value complex128) []Appender {
+	var list []Appender
+	list = append(list, g.StartObject()...)
+	list = append(list, g.Key("re")...)
+	list = append(list, g.FloatValue(real(value))...)
+	list = append(list, g.Key("im")...)
+	list = append(list, g.FloatValue(imag(value))...)
+	list = append(list, g.EndObject()...)
+	return list
+}
+
+func (g *Generator) StringValue(value string) []Appender {
+	return g.value(StringAppender{Value: value, EscapeHTML: g.javascript.EscapeHTML})
+}
+
+func (g *Generator) BytesValue(value []byte) []Appender {
+	return g.value(BytesAppender{Value: value})
+}
+
+func (g *Generator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *Generator) RuneValue(value rune) []Appender {
+	return g.StringValue(string(value))
+}
+
+func (g *Generator) TimeValue(value time.Time) []Appender {
+	str := value.UTC().Format("2006-01-02T15:04:05.000Z")
+	return g.literal(`new Date("` + str + `")`)
+}
+
+func (g *Generator) startContainer(open byte, next states.State) []Appender {
+	g.sm.ExpectValue()
+
+	var b appenders.Builder
+	g.separator(&b)
+	g.sm.Push(next)
+	b.AddByte(open)
+	return b.Build()
+}
+
+func (g *Generator) endContainer(close byte, nonEmpty states.State) []Appender {
+	needIndent := g.sm.State.In(nonEmpty)
+	g.sm.Pop()
+
+	var b appenders.Builder
+	if needIndent {
+		g.indent(&b)
+	}
+	b.AddByte(close)
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) separator(b *appenders.Builder) {
+	if g.sm.State.In(states.ArrayFirstValue) {
+		g.indent(b)
+	}
+	if g.sm.State.In(states.ArrayNextValue) {
+		b.AddByte(',')
+		g.indentOrSpace(b)
+	}
+}
+
+func (g *Generator) value(a Appender) []Appender {
+	g.sm.ExpectValue()
+
+	var b appenders.Builder
+	g.separator(&b)
+	b.Add(a)
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) literal(str string) []Appender {
+	return g.value(appenders.LiteralString(str))
+}
+
+func (g *Generator) indent(b *appenders.Builder) {
+	g.javascript.Format.Indent(b, g.javascript.IndentWithTabs, g.javascript.IndentSize, g.sm.Depth())
+}
+
+func (g *Generator) indentOrSpace(b *appenders.Builder) {
+	g.javascript.Format.IndentOrSpace(b, g.javascript.IndentWithTabs, g.javascript.IndentSize, g.sm.Depth())
+}
+
+var _ emitter.Generator = (*Generator)(nil)
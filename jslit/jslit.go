@@ -0,0 +1,21 @@
+package jslit
+
+import (
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/json"
+)
+
+type JavaScript struct {
+	Format         json.Format
+	IndentSize     uint
+	IndentWithTabs bool
+	EscapeHTML     bool
+}
+
+func (javascript JavaScript) NewGenerator() emitter.Generator {
+	g := &Generator{javascript: javascript}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = JavaScript{}
@@ -0,0 +1,86 @@
+package jslit
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/json"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+func TestJavaScript(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Input     Value
+		Factory   emitter.GeneratorFactory
+		Expect    string
+		ExpectErr error
+	}
+
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	kFancyValue := values.Object{
+		{Key: "none", Value: values.Null{}},
+		{Key: "big-ints", Value: values.Array{
+			values.BigIntValue{Pointer: huge},
+			values.Int(math.MaxInt64),
+			values.Int(42),
+		}},
+		{Key: "text", Value: values.String("</script>\u2028")},
+		{Key: "raw", Value: values.Bytes("ab")},
+		{Key: "nums", Value: values.Array{
+			values.Float(math.NaN()),
+			values.Float(math.Inf(-1)),
+			values.BigRatValue{Pointer: big.NewRat(1, 3)},
+		}},
+		{Key: "when", Value: values.Time(time.Date(2023, 9, 1, 12, 30, 0, 500000000, time.UTC))},
+	}
+
+	testData := [...]testCase{
+		{
+			Name:    "Compact",
+			Input:   kFancyValue,
+			Factory: JavaScript{EscapeHTML: true},
+			Expect:  "{none:null,\"big-ints\":[123456789012345678901234567890n,9223372036854775807n,42],text:\"\\u003c/script\\u003e\\u2028\",raw:Uint8Array.from([97, 98]),nums:[NaN,-Infinity,1 / 3],when:new Date(\"2023-09-01T12:30:00.500Z\")}",
+		},
+		{
+			Name:    "MultiLine",
+			Input:   values.Array{values.Object{{Key: "a", Value: values.Int(1)}}, values.Array(nil)},
+			Factory: JavaScript{Format: json.MultiLine, IndentSize: 2},
+			Expect:  "[\n  {\n    a: 1\n  },\n  []\n]\n",
+		},
+		{
+			Name:    "Proto",
+			Input:   values.Object{{Key: "__proto__", Value: values.Int(1)}, {Key: "__proto", Value: values.Int(2)}},
+			Factory: JavaScript{},
+			Expect:  "{[\"__proto__\"]:1,__proto:2}",
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, row.Factory.NewGenerator())
+			row.Input.EmitTo(&e)
+			err := e.Close()
+
+			if !reflect.DeepEqual(err, row.ExpectErr) {
+				t.Errorf("wrong error:\n\texpect: %#v\n\tactual: %#v", row.ExpectErr, err)
+				return
+			}
+
+			if actual := buf.String(); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
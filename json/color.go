@@ -0,0 +1,59 @@
+package json
+
+import (
+	"fmt"
+)
+
+// Theme holds the ANSI SGR parameters (e.g. "1;34") used for each class of
+// token when colorized output is enabled.  An empty string leaves that
+// class uncolored.
+type Theme struct {
+	Key         string
+	String      string
+	Number      string
+	Bool        string
+	Null        string
+	Punctuation string
+}
+
+// DefaultTheme matches the default colors of jq.
+var DefaultTheme = Theme{
+	Key:         "34;1",
+	String:      "0;32",
+	Number:      "0;39",
+	Bool:        "0;39",
+	Null:        "1;30",
+	Punctuation: "1;39",
+}
+
+// UseColor implements the NO_COLOR convention: it returns true only if the
+// output is a terminal and noColor, the value of the caller's NO_COLOR
+// variable, is empty.
+func UseColor(noColor string, isTerminal bool) bool {
+	return isTerminal && noColor == ""
+}
+
+type ColorAppender struct {
+	SGR   string
+	Inner Appender
+}
+
+func (a ColorAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a ColorAppender) Append(out []byte) []byte {
+	if a.SGR == "" {
+		return a.Inner.Append(out)
+	}
+	out = append(out, "\x1b["...)
+	out = append(out, a.SGR...)
+	out = append(out, 'm')
+	out = a.Inner.Append(out)
+	return append(out, "\x1b[0m"...)
+}
+
+var (
+	_ fmt.Stringer = ColorAppender{}
+	_ Appender     = ColorAppender{}
+)
@@ -60,7 +60,7 @@ func (f *Format) UnmarshalText(input []byte) error {
 	return f.Parse(string(input))
 }
 
-func (f Format) indent(b *appenders.Builder, tabs bool, size uint, count uint) {
+func (f Format) Indent(b *appenders.Builder, tabs bool, size uint, count uint) {
 	switch f {
 	case MultiLine:
 		b.AddByte('\n')
@@ -68,7 +68,7 @@ func (f Format) indent(b *appenders.Builder, tabs bool, size uint, count uint) {
 	}
 }
 
-func (f Format) indentOrSpace(b *appenders.Builder, tabs bool, size uint, count uint) {
+func (f Format) IndentOrSpace(b *appenders.Builder, tabs bool, size uint, count uint) {
 	switch f {
 	case MultiLine:
 		b.AddByte('\n')
@@ -78,7 +78,7 @@ func (f Format) indentOrSpace(b *appenders.Builder, tabs bool, size uint, count
 	}
 }
 
-func (f Format) space(b *appenders.Builder) {
+func (f Format) Space(b *appenders.Builder) {
 	switch f {
 	case MultiLine:
 		fallthrough
@@ -87,7 +87,7 @@ func (f Format) space(b *appenders.Builder) {
 	}
 }
 
-func (f Format) lineFeed(b *appenders.Builder) {
+func (f Format) LineFeed(b *appenders.Builder) {
 	switch f {
 	case MultiLine:
 		fallthrough
@@ -2,8 +2,10 @@ package json
 
 import (
 	"fmt"
+	"math"
 	"math/big"
 	"os"
+	"time"
 
 	"github.com/chronos-tachyon/go-emitter"
 	"github.com/chronos-tachyon/go-emitter/appenders"
@@ -44,10 +46,11 @@ func (g *Generator) End() []Appender {
 func (g *Generator) StartObject() []Appender {
 	g.trace("StartObject#1")
 	g.sm.ExpectValue()
-	g.sm.Push(states.ObjectFirstKey)
 
 	var b appenders.Builder
-	b.AddByte('{')
+	g.separator(&b)
+	g.sm.Push(states.ObjectFirstKey)
+	g.punct(&b, "{")
 	g.trace("StartObject#2")
 	return b.Build()
 }
@@ -62,7 +65,7 @@ func (g *Generator) EndObject() []Appender {
 	if needIndent {
 		g.indent(&b)
 	}
-	b.AddByte('}')
+	g.punct(&b, "}")
 	g.sm.Next()
 	g.trace("EndObject#2")
 	return b.Build()
@@ -71,10 +74,11 @@ func (g *Generator) EndObject() []Appender {
 func (g *Generator) StartArray() []Appender {
 	g.trace("StartArray#1")
 	g.sm.ExpectValue()
-	g.sm.Push(states.ArrayFirstValue)
 
 	var b appenders.Builder
-	b.AddByte('[')
+	g.separator(&b)
+	g.sm.Push(states.ArrayFirstValue)
+	g.punct(&b, "[")
 	g.trace("StartArray#2")
 	return b.Build()
 }
@@ -89,7 +93,7 @@ func (g *Generator) EndArray() []Appender {
 	if needIndent {
 		g.indent(&b)
 	}
-	b.AddByte(']')
+	g.punct(&b, "]")
 	g.sm.Next()
 	g.trace("EndArray#2")
 	return b.Build()
@@ -104,11 +108,11 @@ func (g *Generator) Key(key string) []Appender {
 		g.indent(&b)
 	}
 	if g.sm.State.In(states.ObjectNextKey) {
-		b.AddByte(',')
+		g.punct(&b, ",")
 		g.indentOrSpace(&b)
 	}
-	b.Add(StringAppender{Value: key, EscapeHTML: g.json.EscapeHTML})
-	b.AddByte(':')
+	b.Add(g.color(g.theme().Key, StringAppender{Value: key, EscapeHTML: g.json.EscapeHTML}))
+	g.punct(&b, ":")
 	g.space(&b)
 	g.sm.Next()
 	g.trace("Key#2")
@@ -116,22 +120,22 @@ func (g *Generator) Key(key string) []Appender {
 }
 
 func (g *Generator) NullValue() []Appender {
-	return g.literal(`null`)
+	return g.literal(g.theme().Null, `null`)
 }
 
 func (g *Generator) BoolValue(value bool) []Appender {
 	if value {
-		return g.literal(`true`)
+		return g.literal(g.theme().Bool, `true`)
 	}
-	return g.literal(`false`)
+	return g.literal(g.theme().Bool, `false`)
 }
 
 func (g *Generator) StringValue(value string) []Appender {
-	return g.value(StringAppender{Value: value, EscapeHTML: g.json.EscapeHTML})
+	return g.value(g.color(g.theme().String, StringAppender{Value: value, EscapeHTML: g.json.EscapeHTML}))
 }
 
 func (g *Generator) BytesValue(value []byte) []Appender {
-	return g.value(BytesAppender{Value: value})
+	return g.value(g.color(g.theme().String, BytesAppender{Value: value}))
 }
 
 func (g *Generator) ByteValue(value byte) []Appender {
@@ -142,41 +146,78 @@ func (g *Generator) RuneValue(value rune) []Appender {
 	return g.StringValue(string(value))
 }
 
+func (g *Generator) TimeValue(value time.Time) []Appender {
+	return g.StringValue(value.Format(time.RFC3339Nano))
+}
+
 func (g *Generator) IntValue(value int64) []Appender {
-	return g.value(appenders.IntText(value))
+	return g.number(appenders.IntText(value))
 }
 
 func (g *Generator) UintValue(value uint64) []Appender {
-	return g.value(appenders.UintText(value))
+	return g.number(appenders.UintText(value))
 }
 
 func (g *Generator) NaNValue() []Appender {
-	return g.literal(`"NaN"`)
+	return g.literal(g.theme().String, `"NaN"`)
 }
 
 func (g *Generator) InfValue(isNeg bool) []Appender {
 	if isNeg {
-		return g.literal(`"-Inf"`)
+		return g.literal(g.theme().String, `"-Inf"`)
 	}
-	return g.literal(`"+Inf"`)
+	return g.literal(g.theme().String, `"+Inf"`)
 }
 
 func (g *Generator) FloatValue(value float64) []Appender {
-	return g.value(appenders.FloatText(value))
+	return g.number(appenders.FloatText(value))
 }
 
 func (g *Generator) BigIntValue(value *big.Int) []Appender {
 	if value == nil {
 		return g.NullValue()
 	}
-	return g.value(appenders.BigIntText{Pointer: value})
+	return g.number(appenders.BigIntText{Pointer: value})
 }
 
 func (g *Generator) BigFloatValue(value *big.Float) []Appender {
 	if value == nil {
 		return g.NullValue()
 	}
-	return g.value(appenders.BigFloatText{Pointer: value})
+	return g.number(appenders.BigFloatText{Pointer: value})
+}
+
+func (g *Generator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if _, ok := appenders.TerminatingDigits(value); ok {
+		return g.number(appenders.BigRatDecimalText{Pointer: value})
+	}
+	str := string(appenders.BigRatText{Pointer: value}.Append(nil))
+	return g.StringValue(str)
+}
+
+func (g *Generator) ComplexValue(value complex128) []Appender {
+	var list []Appender
+	list = append(list, g.StartObject()...)
+	list = append(list, g.Key("re")...)
+	list = append(list, g.floatValue(real(value))...)
+	list = append(list, g.Key("im")...)
+	list = append(list, g.floatValue(imag(value))...)
+	list = append(list, g.EndObject()...)
+	return list
+}
+
+func (g *Generator) floatValue(value float64) []Appender {
+	switch {
+	case math.IsNaN(value):
+		return g.NaNValue()
+	case math.IsInf(value, 0):
+		return g.InfValue(value < 0)
+	default:
+		return g.FloatValue(value)
+	}
 }
 
 func (g *Generator) value(a Appender) []Appender {
@@ -184,37 +225,67 @@ func (g *Generator) value(a Appender) []Appender {
 	g.sm.ExpectValue()
 
 	var b appenders.Builder
-	if g.sm.State.In(states.ArrayFirstValue) {
-		g.indent(&b)
-	}
-	if g.sm.State.In(states.ArrayNextValue) {
-		b.AddByte(',')
-		g.indentOrSpace(&b)
-	}
+	g.separator(&b)
 	b.Add(a)
 	g.sm.Next()
 	g.trace("value#2")
 	return b.Build()
 }
 
-func (g *Generator) literal(str string) []Appender {
-	return g.value(appenders.LiteralString(str))
+func (g *Generator) separator(b *appenders.Builder) {
+	if g.sm.State.In(states.ArrayFirstValue) {
+		g.indent(b)
+	}
+	if g.sm.State.In(states.ArrayNextValue) {
+		g.punct(b, ",")
+		g.indentOrSpace(b)
+	}
+}
+
+func (g *Generator) number(a Appender) []Appender {
+	return g.value(g.color(g.theme().Number, a))
+}
+
+func (g *Generator) literal(sgr string, str string) []Appender {
+	return g.value(g.color(sgr, appenders.LiteralString(str)))
+}
+
+func (g *Generator) punct(b *appenders.Builder, str string) {
+	if g.json.Color {
+		b.Add(g.color(g.theme().Punctuation, appenders.LiteralString(str)))
+		return
+	}
+	b.AddString(str)
+}
+
+func (g *Generator) color(sgr string, a Appender) Appender {
+	if g.json.Color && sgr != "" {
+		return ColorAppender{SGR: sgr, Inner: a}
+	}
+	return a
+}
+
+func (g *Generator) theme() *Theme {
+	if g.json.Theme != nil {
+		return g.json.Theme
+	}
+	return &DefaultTheme
 }
 
 func (g *Generator) indent(b *appenders.Builder) {
-	g.json.Format.indent(b, g.json.IndentWithTabs, g.json.IndentSize, g.sm.Depth())
+	g.json.Format.Indent(b, g.json.IndentWithTabs, g.json.IndentSize, g.sm.Depth())
 }
 
 func (g *Generator) indentOrSpace(b *appenders.Builder) {
-	g.json.Format.indentOrSpace(b, g.json.IndentWithTabs, g.json.IndentSize, g.sm.Depth())
+	g.json.Format.IndentOrSpace(b, g.json.IndentWithTabs, g.json.IndentSize, g.sm.Depth())
 }
 
 func (g *Generator) space(b *appenders.Builder) {
-	g.json.Format.space(b)
+	g.json.Format.Space(b)
 }
 
 func (g *Generator) lineFeed(b *appenders.Builder) {
-	g.json.Format.lineFeed(b)
+	g.json.Format.LineFeed(b)
 }
 
 func (g *Generator) trace(call string) {
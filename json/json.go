@@ -9,6 +9,8 @@ type JSON struct {
 	IndentSize     uint
 	IndentWithTabs bool
 	EscapeHTML     bool
+	Color          bool
+	Theme          *Theme
 	TraceEnabled   bool
 }
 
@@ -2,8 +2,10 @@ package json
 
 import (
 	"bytes"
+	"math/big"
 	"reflect"
 	"testing"
+	"time"
 	"unicode/utf8"
 
 	"github.com/chronos-tachyon/go-emitter"
@@ -22,6 +24,11 @@ var (
 	kStringValue Value = values.String("abc")
 	kBytesValue  Value = values.Bytes("abc")
 
+	kRatValue      Value = values.BigRatValue{Pointer: big.NewRat(-5, 8)}
+	kFractionValue Value = values.BigRatValue{Pointer: big.NewRat(1, 3)}
+	kComplexValue  Value = values.Complex(complex(1.5, -2))
+	kTimeValue     Value = values.Time(time.Date(2023, 9, 1, 12, 30, 0, 500000000, time.UTC))
+
 	kArrayValue Value = values.Array{
 		values.Rune('a'),
 		values.Rune('b'),
@@ -34,6 +41,13 @@ var (
 		{"c", values.Int(3)},
 	}
 
+	kMapValue Value = values.Map{
+		{Key: values.Int(1), Value: values.String("one")},
+		{Key: values.Bool(true), Value: values.String("yes")},
+		{Key: values.Null{}, Value: values.String("nothing")},
+		{Key: values.Float(2.5), Value: values.String("half")},
+	}
+
 	kFancyValue Value = values.Object{
 		{"@type", values.String("Foo")},
 		{"emptyList", values.Array(nil)},
@@ -41,6 +55,12 @@ var (
 		{"array", kArrayValue},
 		{"object", kObjectValue},
 	}
+
+	kNestedValue Value = values.Array{
+		values.Int(5),
+		values.Object{{Key: "x", Value: values.Null{}}},
+		values.Array{values.Array(nil)},
+	}
 )
 
 func TestJSON(t *testing.T) {
@@ -55,6 +75,7 @@ func TestJSON(t *testing.T) {
 	compactJSON := JSON{}
 	oneLineJSON := JSON{Format: OneLine}
 	multiLineJSON := JSON{Format: MultiLine}
+	colorJSON := JSON{Color: true, Theme: &Theme{Key: "34", String: "32", Number: "33", Null: "90"}}
 
 	testData := [...]testCase{
 		{
@@ -105,6 +126,30 @@ func TestJSON(t *testing.T) {
 			Factory: compactJSON,
 			Expect:  []byte(`"YWJj"`),
 		},
+		{
+			Name:    "Compact/Rat",
+			Input:   kRatValue,
+			Factory: compactJSON,
+			Expect:  []byte(`-0.625`),
+		},
+		{
+			Name:    "Compact/Fraction",
+			Input:   kFractionValue,
+			Factory: compactJSON,
+			Expect:  []byte(`"1/3"`),
+		},
+		{
+			Name:    "Compact/Complex",
+			Input:   kComplexValue,
+			Factory: compactJSON,
+			Expect:  []byte(`{"re":1.5,"im":-2}`),
+		},
+		{
+			Name:    "Compact/Time",
+			Input:   kTimeValue,
+			Factory: compactJSON,
+			Expect:  []byte(`"2023-09-01T12:30:00.5Z"`),
+		},
 		{
 			Name:    "Compact/Array",
 			Input:   kArrayValue,
@@ -117,12 +162,39 @@ func TestJSON(t *testing.T) {
 			Factory: compactJSON,
 			Expect:  []byte(`{"a":1,"b":2,"c":3}`),
 		},
+		{
+			Name:    "Compact/Map",
+			Input:   kMapValue,
+			Factory: compactJSON,
+			Expect:  []byte(`{"1":"one","true":"yes","null":"nothing","2.5":"half"}`),
+		},
 		{
 			Name:    "Compact/Fancy",
 			Input:   kFancyValue,
 			Factory: compactJSON,
 			Expect:  []byte(`{"@type":"Foo","emptyList":[],"emptyObject":{},"array":["a","b","c"],"object":{"a":1,"b":2,"c":3}}`),
 		},
+		{
+			Name:    "Compact/Nested",
+			Input:   kNestedValue,
+			Factory: compactJSON,
+			Expect:  []byte(`[5,{"x":null},[[]]]`),
+		},
+		{
+			Name: "Color/Object",
+			Input: values.Object{
+				{Key: "a", Value: values.Array{values.Int(1), values.Bool(true), values.Null{}}},
+				{Key: "b", Value: values.String("\x1b[31m")},
+			},
+			Factory: colorJSON,
+			Expect:  []byte("{\x1b[34m\"a\"\x1b[0m:[\x1b[33m1\x1b[0m,true,\x1b[90mnull\x1b[0m],\x1b[34m\"b\"\x1b[0m:\x1b[32m\"\\u001b[31m\"\x1b[0m}"),
+		},
+		{
+			Name:    "Color/Default",
+			Input:   values.Array{values.Float(0.5)},
+			Factory: JSON{Color: true},
+			Expect:  []byte("\x1b[1;39m[\x1b[0m\x1b[0;39m0.5\x1b[0m\x1b[1;39m]\x1b[0m"),
+		},
 
 		{
 			Name:    "OneLine/Null",
@@ -172,6 +244,12 @@ func TestJSON(t *testing.T) {
 			Factory: oneLineJSON,
 			Expect:  ParseOneLine(`"YWJj"`),
 		},
+		{
+			Name:    "OneLine/Complex",
+			Input:   kComplexValue,
+			Factory: oneLineJSON,
+			Expect:  ParseOneLine(`{"re": 1.5, "im": -2}`),
+		},
 		{
 			Name:    "OneLine/Array",
 			Input:   kArrayValue,
@@ -285,6 +363,22 @@ func TestJSON(t *testing.T) {
 			|}
 			`),
 		},
+		{
+			Name:    "MultiLine/Nested",
+			Input:   kNestedValue,
+			Factory: multiLineJSON,
+			Expect: ParseMultiLine(`
+			|[
+			|  5,
+			|  {
+			|    "x": null
+			|  },
+			|  [
+			|    []
+			|  ]
+			|]
+			`),
+		},
 	}
 
 	var buf bytes.Buffer
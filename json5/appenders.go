@@ -0,0 +1,96 @@
+package json5
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/json"
+)
+
+type Appender = appenders.Appender
+
+type StringAppender struct {
+	Value        string
+	EscapeHTML   bool
+	SingleQuoted bool
+}
+
+func (a StringAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a StringAppender) Append(out []byte) []byte {
+	quoted := json.StringAppender{Value: a.Value, EscapeHTML: a.EscapeHTML}.Append(nil)
+	if !a.SingleQuoted {
+		return append(out, quoted...)
+	}
+
+	// Re-quote the JSON form: \" no longer needs escaping, but ' does.
+	body := quoted[1 : len(quoted)-1]
+	out = append(out, '\'')
+	for i := 0; i < len(body); i++ {
+		switch ch := body[i]; {
+		case ch == '\\' && body[i+1] == '"':
+			out = append(out, '"')
+			i++
+		case ch == '\\':
+			out = append(out, ch, body[i+1])
+			i++
+		case ch == '\'':
+			out = append(out, '\\', '\'')
+		default:
+			out = append(out, ch)
+		}
+	}
+	return append(out, '\'')
+}
+
+var (
+	_ fmt.Stringer = StringAppender{}
+	_ Appender     = StringAppender{}
+)
+
+type KeyAppender struct {
+	Value        string
+	EscapeHTML   bool
+	SingleQuoted bool
+}
+
+func (a KeyAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a KeyAppender) Append(out []byte) []byte {
+	if IsIdentifier(a.Value) {
+		return append(out, a.Value...)
+	}
+	return StringAppender(a).Append(out)
+}
+
+var (
+	_ fmt.Stringer = KeyAppender{}
+	_ Appender     = KeyAppender{}
+)
+
+// IsIdentifier reports whether str is an ECMAScript 5.1 IdentifierName, and
+// can therefore be written as an unquoted JSON5 key.
+func IsIdentifier(str string) bool {
+	if str == "" || !utf8.ValidString(str) {
+		return false
+	}
+	for index, ch := range str {
+		switch {
+		case ch == '$' || ch == '_':
+		case unicode.In(ch, unicode.Lu, unicode.Ll, unicode.Lt, unicode.Lm, unicode.Lo, unicode.Nl):
+		case index == 0:
+			return false
+		case ch == '\u200c' || ch == '\u200d':
+		case unicode.In(ch, unicode.Mn, unicode.Mc, unicode.Nd, unicode.Pc):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,2 @@
+// Package json5 implements the JSON5 format for Emitter.
+package json5
@@ -0,0 +1,261 @@
+package json5
+
+import (
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/json"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+type Generator struct {
+	json5 JSON5
+	sm    states.Machine
+}
+
+func (g *Generator) Reset() {
+	g.sm.Reset()
+}
+
+func (g *Generator) Factory() emitter.GeneratorFactory {
+	return g.json5
+}
+
+func (g *Generator) Begin() []Appender {
+	g.sm.ExpectRoot()
+	return nil
+}
+
+func (g *Generator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+
+	var b appenders.Builder
+	g.json5.Format.LineFeed(&b)
+	return b.Build()
+}
+
+func (g *Generator) StartObject() []Appender {
+	g.sm.ExpectValue()
+
+	var b appenders.Builder
+	g.separator(&b)
+	g.sm.Push(states.ObjectFirstKey)
+	b.AddByte('{')
+	return b.Build()
+}
+
+func (g *Generator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	return g.endContainer('}', states.ObjectNextKey)
+}
+
+func (g *Generator) StartArray() []Appender {
+	g.sm.ExpectValue()
+
+	var b appenders.Builder
+	g.separator(&b)
+	g.sm.Push(states.ArrayFirstValue)
+	b.AddByte('[')
+	return b.Build()
+}
+
+func (g *Generator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	return g.endContainer(']', states.ArrayNextValue)
+}
+
+func (g *Generator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+
+	var b appenders.Builder
+	if g.sm.State.In(states.ObjectFirstKey) {
+		g.indent(&b)
+	}
+	if g.sm.State.In(states.ObjectNextKey) {
+		b.AddByte(',')
+		g.indentOrSpace(&b)
+	}
+	b.Add(KeyAppender{Value: key, EscapeHTML: g.json5.EscapeHTML, SingleQuoted: g.json5.SingleQuotes})
+	b.AddByte(':')
+	g.json5.Format.Space(&b)
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) NullValue() []Appender {
+	return g.literal(`null`)
+}
+
+func (g *Generator) BoolValue(value bool) []Appender {
+	if value {
+		return g.literal(`true`)
+	}
+	return g.literal(`false`)
+}
+
+func (g *Generator) IntValue(value int64) []Appender {
+	if g.json5.HexIntegers {
+		return g.BigIntValue(big.NewInt(value))
+	}
+	return g.value(appenders.IntText(value))
+}
+
+func (g *Generator) UintValue(value uint64) []Appender {
+	if g.json5.HexIntegers {
+		return g.BigIntValue(new(big.Int).SetUint64(value))
+	}
+	return g.value(appenders.UintText(value))
+}
+
+func (g *Generator) BigIntValue(value *big.Int) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if !g.json5.HexIntegers {
+		return g.value(appenders.BigIntText{Pointer: value})
+	}
+
+	var str []byte
+	if value.Sign() < 0 {
+		str = append(str, '-')
+	}
+	str = append(str, '0', 'x')
+	str = new(big.Int).Abs(value).Append(str, 16)
+	return g.value(appenders.LiteralBytes(str))
+}
+
+func (g *Generator) NaNValue() []Appender {
+	return g.literal(`NaN`)
+}
+
+func (g *Generator) InfValue(isNeg bool) []Appender {
+	if isNeg {
+		return g.literal(`-Infinity`)
+	}
+	return g.literal(`Infinity`)
+}
+
+func (g *Generator) FloatValue(value float64) []Appender {
+	return g.value(appenders.FloatText(value))
+}
+
+func (g *Generator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if value.IsInf() {
+		return g.InfValue(value.Signbit())
+	}
+	return g.value(appenders.BigFloatText{Pointer: value})
+}
+
+func (g *Generator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if _, ok := appenders.TerminatingDigits(value); ok {
+		return g.value(appenders.BigRatDecimalText{Pointer: value})
+	}
+	str := string(appenders.BigRatText{Pointer: value}.Append(nil))
+	return g.StringValue(str)
+}
+
+func (g *Generator) ComplexValue(value complex128) []Appender {
+	var list []Appender
+	list = append(list, g.StartObject()...)
+	list = append(list, g.Key("re")...)
+	list = append(list, g.floatValue(real(value))...)
+	list = append(list, g.Key("im")...)
+	list = append(list, g.floatValue(imag(value))...)
+	list = append(list, g.EndObject()...)
+	return list
+}
+
+func (g *Generator) StringValue(value string) []Appender {
+	return g.value(StringAppender{Value: value, EscapeHTML: g.json5.EscapeHTML, SingleQuoted: g.json5.SingleQuotes})
+}
+
+func (g *Generator) BytesValue(value []byte) []Appender {
+	if g.json5.SingleQuotes {
+		str := json.BytesAppender{Value: value}.String()
+		return g.value(appenders.LiteralString("'" + str[1:len(str)-1] + "'"))
+	}
+	return g.value(json.BytesAppender{Value: value})
+}
+
+func (g *Generator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *Generator) RuneValue(value rune) []Appender {
+	return g.StringValue(string(value))
+}
+
+func (g *Generator) TimeValue(value time.Time) []Appender {
+	return g.StringValue(value.Format(time.RFC3339Nano))
+}
+
+func (g *Generator) floatValue(value float64) []Appender {
+	switch {
+	case math.IsNaN(value):
+		return g.NaNValue()
+	case math.IsInf(value, 0):
+		return g.InfValue(value < 0)
+	default:
+		return g.FloatValue(value)
+	}
+}
+
+func (g *Generator) endContainer(close byte, nonEmpty states.State) []Appender {
+	needIndent := g.sm.State.In(nonEmpty)
+	g.sm.Pop()
+
+	var b appenders.Builder
+	if needIndent {
+		if g.json5.Format == json.MultiLine {
+			b.AddByte(',')
+		}
+		g.indent(&b)
+	}
+	b.AddByte(close)
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) separator(b *appenders.Builder) {
+	if g.sm.State.In(states.ArrayFirstValue) {
+		g.indent(b)
+	}
+	if g.sm.State.In(states.ArrayNextValue) {
+		b.AddByte(',')
+		g.indentOrSpace(b)
+	}
+}
+
+func (g *Generator) value(a Appender) []Appender {
+	g.sm.ExpectValue()
+
+	var b appenders.Builder
+	g.separator(&b)
+	b.Add(a)
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) literal(str string) []Appender {
+	return g.value(appenders.LiteralString(str))
+}
+
+func (g *Generator) indent(b *appenders.Builder) {
+	g.json5.Format.Indent(b, g.json5.IndentWithTabs, g.json5.IndentSize, g.sm.Depth())
+}
+
+func (g *Generator) indentOrSpace(b *appenders.Builder) {
+	g.json5.Format.IndentOrSpace(b, g.json5.IndentWithTabs, g.json5.IndentSize, g.sm.Depth())
+}
+
+var _ emitter.Generator = (*Generator)(nil)
@@ -0,0 +1,23 @@
+package json5
+
+import (
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/json"
+)
+
+type JSON5 struct {
+	Format         json.Format
+	IndentSize     uint
+	IndentWithTabs bool
+	EscapeHTML     bool
+	SingleQuotes   bool
+	HexIntegers    bool
+}
+
+func (json5 JSON5) NewGenerator() emitter.Generator {
+	g := &Generator{json5: json5}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = JSON5{}
@@ -0,0 +1,83 @@
+package json5
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/json"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+func TestJSON5(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Input     Value
+		Factory   emitter.GeneratorFactory
+		Expect    string
+		ExpectErr error
+	}
+
+	kFancyValue := values.Object{
+		{Key: "name", Value: values.String(`it's "here"`)},
+		{Key: "$id", Value: values.Int(-255)},
+		{Key: "2nd", Value: values.Float(math.NaN())},
+		{Key: "a-b", Value: values.Array{values.Float(math.Inf(1)), values.Float(math.Inf(-1)), values.Uint(16)}},
+		{Key: "empty", Value: values.Object(nil)},
+	}
+
+	testData := [...]testCase{
+		{
+			Name:    "Compact",
+			Input:   kFancyValue,
+			Factory: JSON5{},
+			Expect:  `{name:"it's \"here\"",$id:-255,"2nd":NaN,"a-b":[Infinity,-Infinity,16],empty:{}}`,
+		},
+		{
+			Name:    "SingleQuotes",
+			Input:   kFancyValue,
+			Factory: JSON5{SingleQuotes: true, HexIntegers: true, Format: json.OneLine},
+			Expect:  `{name: 'it\'s "here"', $id: -0xff, '2nd': NaN, 'a-b': [Infinity, -Infinity, 0x10], empty: {}}` + "\n",
+		},
+		{
+			Name:    "MultiLine",
+			Input:   kFancyValue,
+			Factory: JSON5{Format: json.MultiLine, IndentSize: 2},
+			Expect: "{\n" +
+				"  name: \"it's \\\"here\\\"\",\n" +
+				"  $id: -255,\n" +
+				"  \"2nd\": NaN,\n" +
+				"  \"a-b\": [\n" +
+				"    Infinity,\n" +
+				"    -Infinity,\n" +
+				"    16,\n" +
+				"  ],\n" +
+				"  empty: {},\n" +
+				"}\n",
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, row.Factory.NewGenerator())
+			row.Input.EmitTo(&e)
+			err := e.Close()
+
+			if !reflect.DeepEqual(err, row.ExpectErr) {
+				t.Errorf("wrong error:\n\texpect: %#v\n\tactual: %#v", row.ExpectErr, err)
+				return
+			}
+
+			if actual := buf.String(); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
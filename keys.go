@@ -0,0 +1,171 @@
+package emitter
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+// FormatKey converts a scalar key to a string, for use with Generators that
+// only accept string keys.  Null becomes "null", booleans become "true" or
+// "false", numbers use their shortest exact decimal form (rationals without a
+// terminating decimal form become "num/den", NaN and infinities become "NaN",
+// "+Inf", and "-Inf"), bytes use standard base64, runes become the
+// corresponding UTF-8 string, and times use RFC 3339 with nanoseconds.
+//
+// FormatKey panics if value is not a scalar.
+func FormatKey(value any) string {
+	var ks keyStringer
+	ks.Reset()
+	e := Emitter{g: &ks, cur: &ks}
+	e.Emit(value)
+	return ks.finish()
+}
+
+type keyStringer struct {
+	parent Generator
+	sm     states.Machine
+	key    string
+}
+
+func (ks *keyStringer) Reset() {
+	ks.sm.Reset()
+	ks.key = ""
+}
+
+func (ks *keyStringer) Factory() GeneratorFactory {
+	return ks.parent.Factory()
+}
+
+func (ks *keyStringer) Begin() []Appender {
+	panic(ks.sm.State.Unexpected())
+}
+
+func (ks *keyStringer) End() []Appender {
+	panic(fmt.Errorf("unterminated key"))
+}
+
+func (ks *keyStringer) StartObject() []Appender {
+	panic(ks.composite())
+}
+
+func (ks *keyStringer) EndObject() []Appender {
+	panic(ks.composite())
+}
+
+func (ks *keyStringer) StartArray() []Appender {
+	panic(ks.composite())
+}
+
+func (ks *keyStringer) EndArray() []Appender {
+	panic(ks.composite())
+}
+
+func (ks *keyStringer) Key(key string) []Appender {
+	panic(ks.composite())
+}
+
+func (ks *keyStringer) NullValue() []Appender {
+	return ks.set("null")
+}
+
+func (ks *keyStringer) BoolValue(value bool) []Appender {
+	return ks.set(strconv.FormatBool(value))
+}
+
+func (ks *keyStringer) IntValue(value int64) []Appender {
+	return ks.set(strconv.FormatInt(value, 10))
+}
+
+func (ks *keyStringer) UintValue(value uint64) []Appender {
+	return ks.set(strconv.FormatUint(value, 10))
+}
+
+func (ks *keyStringer) BigIntValue(value *big.Int) []Appender {
+	if value == nil {
+		return ks.NullValue()
+	}
+	return ks.set(value.String())
+}
+
+func (ks *keyStringer) NaNValue() []Appender {
+	return ks.set("NaN")
+}
+
+func (ks *keyStringer) InfValue(isNeg bool) []Appender {
+	if isNeg {
+		return ks.set("-Inf")
+	}
+	return ks.set("+Inf")
+}
+
+func (ks *keyStringer) FloatValue(value float64) []Appender {
+	return ks.set(strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+func (ks *keyStringer) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return ks.NullValue()
+	}
+	return ks.set(value.Text('g', -1))
+}
+
+func (ks *keyStringer) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return ks.NullValue()
+	}
+	if _, ok := appenders.TerminatingDigits(value); ok {
+		return ks.set(string(appenders.BigRatDecimalText{Pointer: value}.Append(nil)))
+	}
+	return ks.set(string(appenders.BigRatText{Pointer: value}.Append(nil)))
+}
+
+func (ks *keyStringer) ComplexValue(value complex128) []Appender {
+	return ks.set(strconv.FormatComplex(value, 'g', -1, 128))
+}
+
+func (ks *keyStringer) StringValue(value string) []Appender {
+	return ks.set(value)
+}
+
+func (ks *keyStringer) BytesValue(value []byte) []Appender {
+	return ks.set(base64.StdEncoding.EncodeToString(value))
+}
+
+func (ks *keyStringer) ByteValue(value byte) []Appender {
+	return ks.RuneValue(rune(value))
+}
+
+func (ks *keyStringer) RuneValue(value rune) []Appender {
+	return ks.set(string(value))
+}
+
+func (ks *keyStringer) TimeValue(value time.Time) []Appender {
+	return ks.set(value.Format(time.RFC3339Nano))
+}
+
+func (ks *keyStringer) set(key string) []Appender {
+	ks.sm.ExpectRoot()
+	ks.key = key
+	ks.sm.Next()
+	return nil
+}
+
+func (ks *keyStringer) finish() string {
+	ks.sm.ExpectEnd()
+	return ks.key
+}
+
+func (ks *keyStringer) composite() error {
+	if ks.parent == nil {
+		return fmt.Errorf("keys must be scalars")
+	}
+	return fmt.Errorf("%T does not support composite keys", ks.parent)
+}
+
+var _ Generator = (*keyStringer)(nil)
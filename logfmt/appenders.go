@@ -0,0 +1,67 @@
+package logfmt
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/json"
+)
+
+type Appender = appenders.Appender
+
+// KeyAppender writes a key, replacing any character that is not allowed in
+// a logfmt key (spaces, '=', '"', and control characters) with '_'.
+type KeyAppender struct {
+	Value string
+}
+
+func (a KeyAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a KeyAppender) Append(out []byte) []byte {
+	if a.Value == "" {
+		return append(out, '_')
+	}
+	for _, ch := range a.Value {
+		if needsQuotes(ch) {
+			ch = '_'
+		}
+		out = utf8.AppendRune(out, ch)
+	}
+	return out
+}
+
+var (
+	_ fmt.Stringer = KeyAppender{}
+	_ Appender     = KeyAppender{}
+)
+
+// ValueAppender writes a value bare if it can, and as a quoted string with
+// JSON escapes if it contains spaces, '=', '"', or control characters.
+type ValueAppender struct {
+	Value string
+}
+
+func (a ValueAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a ValueAppender) Append(out []byte) []byte {
+	for _, ch := range a.Value {
+		if needsQuotes(ch) {
+			return json.StringAppender{Value: a.Value}.Append(out)
+		}
+	}
+	return append(out, a.Value...)
+}
+
+var (
+	_ fmt.Stringer = ValueAppender{}
+	_ Appender     = ValueAppender{}
+)
+
+func needsQuotes(ch rune) bool {
+	return ch <= ' ' || ch == '=' || ch == '"' || ch == 0x7f || ch == utf8.RuneError
+}
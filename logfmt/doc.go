@@ -0,0 +1,2 @@
+// Package logfmt implements the logfmt key=value format for Emitter.
+package logfmt
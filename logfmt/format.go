@@ -0,0 +1,66 @@
+package logfmt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/flatten"
+)
+
+type formatter struct {
+	logfmt Logfmt
+	fields uint
+}
+
+func (f *formatter) Factory() emitter.GeneratorFactory {
+	return f.logfmt
+}
+
+func (f *formatter) RootError() error {
+	return fmt.Errorf("logfmt records must be objects")
+}
+
+func (f *formatter) Null() string {
+	return "null"
+}
+
+func (f *formatter) StartRecord() []Appender {
+	f.fields = 0
+	return nil
+}
+
+func (f *formatter) EndRecord() []Appender {
+	return []Appender{appenders.LiteralString("\n")}
+}
+
+func (f *formatter) Name(path []string) string {
+	separator := f.logfmt.KeySeparator
+	if separator == "" {
+		separator = "."
+	}
+	return KeyAppender{Value: strings.Join(path, separator)}.String()
+}
+
+func (f *formatter) DuplicateError(name string) error {
+	return fmt.Errorf("logfmt record has more than one field named %q", name)
+}
+
+func (f *formatter) Array(name string) []Appender {
+	return nil
+}
+
+func (f *formatter) Field(name string, value string) []Appender {
+	var b appenders.Builder
+	if f.fields > 0 {
+		b.AddByte(' ')
+	}
+	b.AddString(name)
+	b.AddByte('=')
+	b.Add(ValueAppender{Value: value})
+	f.fields++
+	return b.Build()
+}
+
+var _ flatten.Formatter = (*formatter)(nil)
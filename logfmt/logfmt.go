@@ -0,0 +1,18 @@
+package logfmt
+
+import (
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/flatten"
+)
+
+// Logfmt generates logfmt records.  KeySeparator joins the parts of a
+// flattened key, and defaults to ".".
+type Logfmt struct {
+	KeySeparator string
+}
+
+func (logfmt Logfmt) NewGenerator() emitter.Generator {
+	return flatten.NewStreamGenerator(&formatter{logfmt: logfmt})
+}
+
+var _ emitter.GeneratorFactory = Logfmt{}
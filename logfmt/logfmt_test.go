@@ -0,0 +1,149 @@
+package logfmt
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+func TestLogfmt(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Input     []Value
+		Factory   emitter.GeneratorFactory
+		Expect    string
+		ExpectErr error
+	}
+
+	when := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+
+	testData := [...]testCase{
+		{
+			Name: "Scalars",
+			Input: []Value{values.Object{
+				{Key: "level", Value: values.String("info")},
+				{Key: "msg", Value: values.String("hello world")},
+				{Key: "n", Value: values.Int(-3)},
+				{Key: "ok", Value: values.Bool(true)},
+				{Key: "ratio", Value: values.Float(0.5)},
+				{Key: "inf", Value: values.Float(math.Inf(1))},
+				{Key: "third", Value: values.BigRatValue{Pointer: big.NewRat(1, 3)}},
+				{Key: "err", Value: values.Null{}},
+				{Key: "at", Value: values.Time(when)},
+				{Key: "data", Value: values.Bytes("hi")},
+			}},
+			Factory: Logfmt{},
+			Expect:  "level=info msg=\"hello world\" n=-3 ok=true ratio=0.5 inf=+Inf third=1/3 err=null at=2024-05-06T07:08:09Z data=\"aGk=\"\n",
+		},
+		{
+			Name: "Quoting",
+			Input: []Value{values.Object{
+				{Key: "eq", Value: values.String("a=b")},
+				{Key: "quote", Value: values.String(`say "hi"`)},
+				{Key: "newline", Value: values.String("a\nb")},
+				{Key: "empty", Value: values.String("")},
+				{Key: "path", Value: values.String(`C:\tmp`)},
+				{Key: "bad key=", Value: values.Int(1)},
+				{Key: "", Value: values.Int(2)},
+			}},
+			Factory: Logfmt{},
+			Expect:  "eq=\"a=b\" quote=\"say \\\"hi\\\"\" newline=\"a\\nb\" empty= path=C:\\tmp bad_key_=1 _=2\n",
+		},
+		{
+			Name: "Nested",
+			Input: []Value{values.Object{
+				{Key: "http", Value: values.Object{
+					{Key: "method", Value: values.String("GET")},
+					{Key: "tags", Value: values.Array{values.String("a"), values.Array{values.Int(1), values.Int(2)}}},
+					{Key: "empty", Value: values.Object(nil)},
+				}},
+				{Key: "z", Value: values.Complex(complex(1, -1))},
+			}},
+			Factory: Logfmt{},
+			Expect:  "http.method=GET http.tags.0=a http.tags.1.0=1 http.tags.1.1=2 z.re=1 z.im=-1\n",
+		},
+		{
+			Name:    "Nested/KeySeparator",
+			Input:   []Value{values.Object{{Key: "a", Value: values.Object{{Key: "b", Value: values.Array{values.Int(1)}}}}}},
+			Factory: Logfmt{KeySeparator: "_"},
+			Expect:  "a_b_0=1\n",
+		},
+		{
+			Name: "Stream",
+			Input: []Value{
+				values.Object{{Key: "a", Value: values.Int(1)}, {Key: "b", Value: values.Int(2)}},
+				values.Object(nil),
+				values.Object{{Key: "a", Value: values.Int(3)}},
+			},
+			Factory: Logfmt{},
+			Expect:  "a=1 b=2\n\na=3\n",
+		},
+		{
+			Name:      "Error/Array",
+			Input:     []Value{values.Array{values.Object(nil)}},
+			Factory:   Logfmt{},
+			ExpectErr: fmt.Errorf("logfmt records must be objects"),
+		},
+		{
+			Name:      "Error/Scalar",
+			Input:     []Value{values.Object(nil), values.Int(1)},
+			Factory:   Logfmt{},
+			ExpectErr: fmt.Errorf("logfmt records must be objects"),
+		},
+		{
+			Name: "Error/Duplicate",
+			Input: []Value{values.Object{
+				{Key: "a.b", Value: values.Int(1)},
+				{Key: "a", Value: values.Object{{Key: "b", Value: values.Int(2)}}},
+			}},
+			Factory:   Logfmt{},
+			ExpectErr: fmt.Errorf("logfmt record has more than one field named %q", "a.b"),
+		},
+		{
+			Name: "Error/Duplicate/Sanitized",
+			Input: []Value{values.Object{
+				{Key: "a b", Value: values.Int(1)},
+				{Key: "a_b", Value: values.Int(2)},
+			}},
+			Factory:   Logfmt{},
+			ExpectErr: fmt.Errorf("logfmt record has more than one field named %q", "a_b"),
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, row.Factory.NewGenerator())
+			for index, input := range row.Input {
+				if index > 0 {
+					e.NextDocument()
+				}
+				input.EmitTo(&e)
+			}
+			err := e.Close()
+
+			if !reflect.DeepEqual(err, row.ExpectErr) {
+				t.Errorf("wrong error:\n\texpect: %#v\n\tactual: %#v", row.ExpectErr, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+
+			if actual := buf.String(); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package lua
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chronos-tachyon/go-emitter/appenders"
+)
+
+type Appender = appenders.Appender
+
+var reservedWords = map[string]struct{}{
+	"and": {}, "break": {}, "do": {}, "else": {}, "elseif": {}, "end": {},
+	"false": {}, "for": {}, "function": {}, "goto": {}, "if": {}, "in": {},
+	"local": {}, "nil": {}, "not": {}, "or": {}, "repeat": {}, "return": {},
+	"then": {}, "true": {}, "until": {}, "while": {},
+}
+
+// IsName reports whether str is a Lua name, usable as a bare table key.
+func IsName(str string) bool {
+	if str == "" {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		ch := str[i]
+		switch {
+		case ch == '_':
+		case ch >= 'a' && ch <= 'z':
+		case ch >= 'A' && ch <= 'Z':
+		case ch >= '0' && ch <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	_, reserved := reservedWords[str]
+	return !reserved
+}
+
+// StringAppender writes a Lua string literal.  If Long is set and a long
+// bracket string would be shorter than the quoted form, that is used instead.
+type StringAppender struct {
+	Value  string
+	Long   bool
+	Binary bool
+}
+
+func (a StringAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a StringAppender) Append(out []byte) []byte {
+	quoted := a.appendQuoted(nil)
+	if a.Long {
+		if long, ok := a.long(); ok && len(long) < len(quoted) {
+			return append(out, long...)
+		}
+	}
+	return append(out, quoted...)
+}
+
+func (a StringAppender) appendQuoted(out []byte) []byte {
+	out = append(out, '"')
+	for i := 0; i < len(a.Value); i++ {
+		ch := a.Value[i]
+		switch {
+		case ch == '"' || ch == '\\':
+			out = append(out, '\\', ch)
+		case ch == '\a':
+			out = append(out, '\\', 'a')
+		case ch == '\b':
+			out = append(out, '\\', 'b')
+		case ch == '\f':
+			out = append(out, '\\', 'f')
+		case ch == '\n':
+			out = append(out, '\\', 'n')
+		case ch == '\r':
+			out = append(out, '\\', 'r')
+		case ch == '\t':
+			out = append(out, '\\', 't')
+		case ch == '\v':
+			out = append(out, '\\', 'v')
+		case ch < 0x20 || ch == 0x7f || (a.Binary && ch >= 0x80):
+			out = fmt.Appendf(out, "\\%03d", ch)
+		default:
+			out = append(out, ch)
+		}
+	}
+	return append(out, '"')
+}
+
+// long renders the value as a long bracket string, if it can be represented
+// exactly as one.
+func (a StringAppender) long() (string, bool) {
+	for i := 0; i < len(a.Value); i++ {
+		ch := a.Value[i]
+		if ch == '\r' || (ch < 0x20 && ch != '\n' && ch != '\t') || ch == 0x7f || (a.Binary && ch >= 0x80) {
+			return "", false
+		}
+	}
+
+	for level := 0; ; level++ {
+		eq := strings.Repeat("=", level)
+		closer := "]" + eq + "]"
+		if strings.Index(a.Value+closer, closer) != len(a.Value) {
+			continue
+		}
+
+		var sb strings.Builder
+		sb.WriteString("[" + eq + "[")
+		if strings.HasPrefix(a.Value, "\n") {
+			// The first newline of a long string is discarded.
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(a.Value)
+		sb.WriteString(closer)
+		return sb.String(), true
+	}
+}
+
+var (
+	_ fmt.Stringer = StringAppender{}
+	_ Appender     = StringAppender{}
+)
+
+// FloatAppender writes a finite float so that Lua 5.3+ reads it back as a
+// float, not an integer.
+type FloatAppender float64
+
+func (a FloatAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a FloatAppender) Append(out []byte) []byte {
+	start := len(out)
+	out = strconv.AppendFloat(out, float64(a), 'g', -1, 64)
+	if !strings.ContainsAny(string(out[start:]), ".e") {
+		out = append(out, '.', '0')
+	}
+	return out
+}
+
+var (
+	_ fmt.Stringer = FloatAppender(0)
+	_ Appender     = FloatAppender(0)
+)
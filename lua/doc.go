@@ -0,0 +1,2 @@
+// Package lua implements Lua table constructors for Emitter.
+package lua
@@ -0,0 +1,299 @@
+package lua
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+type Generator struct {
+	lua Lua
+	sm  states.Machine
+}
+
+func (g *Generator) Reset() {
+	g.sm.Reset()
+}
+
+func (g *Generator) Factory() emitter.GeneratorFactory {
+	return g.lua
+}
+
+func (g *Generator) Begin() []Appender {
+	g.sm.ExpectRoot()
+	return nil
+}
+
+func (g *Generator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+
+	var b appenders.Builder
+	g.lua.Format.LineFeed(&b)
+	return b.Build()
+}
+
+func (g *Generator) StartObject() []Appender {
+	return g.startContainer(states.ObjectFirstKey)
+}
+
+func (g *Generator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	return g.endContainer(states.ObjectNextKey)
+}
+
+func (g *Generator) StartArray() []Appender {
+	return g.startContainer(states.ArrayFirstValue)
+}
+
+func (g *Generator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	return g.endContainer(states.ArrayNextValue)
+}
+
+func (g *Generator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+
+	var b appenders.Builder
+	g.keySeparator(&b)
+	if IsName(key) {
+		b.AddString(key)
+	} else {
+		b.AddByte('[')
+		b.Add(StringAppender{Value: key})
+		b.AddByte(']')
+	}
+	g.assign(&b)
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) StartKey() []Appender {
+	var b appenders.Builder
+	g.keySeparator(&b)
+	b.AddByte('[')
+	g.sm.StartKey()
+	return b.Build()
+}
+
+func (g *Generator) EndKey() []Appender {
+	g.sm.EndKey()
+
+	var b appenders.Builder
+	b.AddByte(']')
+	g.assign(&b)
+	return b.Build()
+}
+
+func (g *Generator) NullValue() []Appender {
+	switch {
+	case g.lua.NilSentinel != "":
+		return g.literal(g.lua.NilSentinel)
+	case g.sm.Depth() > 0:
+		return g.invalid(fmt.Errorf("Lua tables cannot hold nil, so null values inside a table require NilSentinel"))
+	default:
+		return g.literal(`nil`)
+	}
+}
+
+func (g *Generator) BoolValue(value bool) []Appender {
+	if value {
+		return g.literal(`true`)
+	}
+	return g.literal(`false`)
+}
+
+func (g *Generator) IntValue(value int64) []Appender {
+	if value == math.MinInt64 {
+		// The literal 9223372036854775808 overflows to a float before
+		// it can be negated, and math.mininteger only exists in Lua 5.3
+		// and later.
+		return g.literal(`(-9223372036854775807-1)`)
+	}
+	return g.value(appenders.IntText(value))
+}
+
+func (g *Generator) UintValue(value uint64) []Appender {
+	if value > math.MaxInt64 {
+		return g.StringValue(strconv.FormatUint(value, 10))
+	}
+	return g.value(appenders.UintText(value))
+}
+
+func (g *Generator) BigIntValue(value *big.Int) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if value.IsInt64() {
+		return g.IntValue(value.Int64())
+	}
+	return g.StringValue(value.String())
+}
+
+func (g *Generator) NaNValue() []Appender {
+	return g.literal(`0/0`)
+}
+
+func (g *Generator) InfValue(isNeg bool) []Appender {
+	if isNeg {
+		return g.literal(`-1/0`)
+	}
+	return g.literal(`1/0`)
+}
+
+func (g *Generator) FloatValue(value float64) []Appender {
+	switch {
+	case math.IsNaN(value):
+		return g.NaNValue()
+	case math.IsInf(value, 0):
+		return g.InfValue(value < 0)
+	default:
+		return g.value(FloatAppender(value))
+	}
+}
+
+func (g *Generator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	f, _ := value.Float64()
+	return g.FloatValue(f)
+}
+
+func (g *Generator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if value.IsInt() {
+		return g.BigIntValue(value.Num())
+	}
+	if _, ok := appenders.TerminatingDigits(value); ok {
+		return g.value(appenders.BigRatDecimalText{Pointer: value})
+	}
+	return g.literal(value.Num().String() + "/" + value.Denom().String())
+}
+
+func (g *Generator) ComplexValue(value complex128) []Appender {
+	var list []Appender
+	list = append(list, g.StartObject()...)
+	list = append(list, g.Key("re")...)
+	list = append(list, g.FloatValue(real(value))...)
+	list = append(list, g.Key("im")...)
+	list = append(list, g.FloatValue(imag(value))...)
+	list = append(list, g.EndObject()...)
+	return list
+}
+
+func (g *Generator) StringValue(value string) []Appender {
+	return g.value(StringAppender{Value: value, Long: g.long()})
+}
+
+func (g *Generator) BytesValue(value []byte) []Appender {
+	return g.value(StringAppender{Value: string(value), Long: g.long(), Binary: true})
+}
+
+func (g *Generator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *Generator) RuneValue(value rune) []Appender {
+	return g.StringValue(string(value))
+}
+
+func (g *Generator) TimeValue(value time.Time) []Appender {
+	return g.StringValue(value.Format(time.RFC3339Nano))
+}
+
+// long reports whether the next string may use a long bracket.  A key in
+// brackets may not, since "[[[" would lex as the start of a long string.
+func (g *Generator) long() bool {
+	return g.lua.LongStrings && !g.sm.State.In(states.KeyRoot)
+}
+
+func (g *Generator) startContainer(next states.State) []Appender {
+	g.sm.ExpectValue()
+
+	var b appenders.Builder
+	g.separator(&b)
+	g.sm.Push(next)
+	b.AddByte('{')
+	return b.Build()
+}
+
+func (g *Generator) endContainer(nonEmpty states.State) []Appender {
+	needIndent := g.sm.State.In(nonEmpty)
+	g.sm.Pop()
+
+	var b appenders.Builder
+	if needIndent {
+		g.indent(&b)
+	}
+	b.AddByte('}')
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) assign(b *appenders.Builder) {
+	g.lua.Format.Space(b)
+	b.AddByte('=')
+	g.lua.Format.Space(b)
+}
+
+func (g *Generator) keySeparator(b *appenders.Builder) {
+	if g.sm.State.In(states.ObjectFirstKey) {
+		g.indent(b)
+	}
+	if g.sm.State.In(states.ObjectNextKey) {
+		b.AddByte(',')
+		g.indentOrSpace(b)
+	}
+}
+
+func (g *Generator) separator(b *appenders.Builder) {
+	if g.sm.State.In(states.ArrayFirstValue) {
+		g.indent(b)
+	}
+	if g.sm.State.In(states.ArrayNextValue) {
+		b.AddByte(',')
+		g.indentOrSpace(b)
+	}
+}
+
+func (g *Generator) value(a Appender) []Appender {
+	g.sm.ExpectValue()
+
+	var b appenders.Builder
+	g.separator(&b)
+	b.Add(a)
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) invalid(err error) []Appender {
+	g.sm.ExpectValue()
+	g.sm.Next()
+	return []Appender{appenders.Error{Err: err}}
+}
+
+func (g *Generator) literal(str string) []Appender {
+	return g.value(appenders.LiteralString(str))
+}
+
+func (g *Generator) indent(b *appenders.Builder) {
+	g.lua.Format.Indent(b, g.lua.IndentWithTabs, g.lua.IndentSize, g.sm.Depth())
+}
+
+func (g *Generator) indentOrSpace(b *appenders.Builder) {
+	g.lua.Format.IndentOrSpace(b, g.lua.IndentWithTabs, g.lua.IndentSize, g.sm.Depth())
+}
+
+var (
+	_ emitter.Generator    = (*Generator)(nil)
+	_ emitter.KeyGenerator = (*Generator)(nil)
+)
@@ -0,0 +1,26 @@
+package lua
+
+import (
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/json"
+)
+
+// Lua generates Lua table constructors.  Since a Lua table cannot hold nil,
+// null values are written as NilSentinel (e.g. "cjson.null"); if NilSentinel
+// is empty, a null value inside a table is an error.  Integers that do not
+// fit in a Lua integer are written as decimal strings.
+type Lua struct {
+	Format         json.Format
+	IndentSize     uint
+	IndentWithTabs bool
+	NilSentinel    string
+	LongStrings    bool
+}
+
+func (lua Lua) NewGenerator() emitter.Generator {
+	g := &Generator{lua: lua}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = Lua{}
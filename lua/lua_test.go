@@ -0,0 +1,130 @@
+package lua
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/json"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+func TestLua(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Input     Value
+		Factory   emitter.GeneratorFactory
+		Expect    string
+		ExpectErr error
+	}
+
+	kFancyValue := values.Object{
+		{Key: "name", Value: values.String("a \"quoted\" \\ value")},
+		{Key: "weird key", Value: values.Null{}},
+		{Key: "end", Value: values.Array{values.Bool(true), values.Null{}, values.Int(math.MinInt64)}},
+		{Key: "raw", Value: values.Bytes("a\x00\xff")},
+		{Key: "nums", Value: values.Array{
+			values.Float(1),
+			values.Float(math.NaN()),
+			values.Float(math.Inf(1)),
+			values.Float(math.Inf(-1)),
+			values.BigRatValue{Pointer: big.NewRat(1, 3)},
+			values.Uint(math.MaxUint64),
+		}},
+	}
+
+	testData := [...]testCase{
+		{
+			Name:    "Compact",
+			Input:   kFancyValue,
+			Factory: Lua{NilSentinel: "cjson.null"},
+			Expect:  "{name=\"a \\\"quoted\\\" \\\\ value\",[\"weird key\"]=cjson.null,[\"end\"]={true,cjson.null,(-9223372036854775807-1)},raw=\"a\\000\\255\",nums={1.0,0/0,1/0,-1/0,1/3,\"18446744073709551615\"}}",
+		},
+		{
+			Name:    "Sentinel",
+			Input:   kFancyValue,
+			Factory: Lua{Format: json.OneLine, NilSentinel: "cjson.null", LongStrings: true},
+			Expect:  "{name = [[a \"quoted\" \\ value]], [\"weird key\"] = cjson.null, [\"end\"] = {true, cjson.null, (-9223372036854775807-1)}, raw = \"a\\000\\255\", nums = {1.0, 0/0, 1/0, -1/0, 1/3, \"18446744073709551615\"}}\n",
+		},
+		{
+			Name:    "Nil/Root",
+			Input:   values.Null{},
+			Factory: Lua{},
+			Expect:  "nil",
+		},
+		{
+			Name:      "Nil/Table",
+			Input:     values.Array{values.Int(1), values.Null{}, values.Int(3)},
+			Factory:   Lua{},
+			ExpectErr: fmt.Errorf("Lua tables cannot hold nil, so null values inside a table require NilSentinel"),
+		},
+		{
+			Name:    "BigInt",
+			Input:   values.BigIntValue{Pointer: new(big.Int).Lsh(big.NewInt(1), 70)},
+			Factory: Lua{},
+			Expect:  "\"1180591620717411303424\"",
+		},
+		{
+			Name:    "MultiLine",
+			Input:   values.Array{values.Object{{Key: "a", Value: values.Int(1)}}, values.Array(nil)},
+			Factory: Lua{Format: json.MultiLine, IndentSize: 2},
+			Expect:  "{\n  {\n    a = 1\n  },\n  {}\n}\n",
+		},
+		{
+			Name: "Map",
+			Input: values.Map{
+				{Key: values.Int(1), Value: values.String("one")},
+				{Key: values.Bool(true), Value: values.String("]]\n")},
+			},
+			Factory: Lua{Format: json.OneLine, LongStrings: true},
+			Expect:  "{[1] = \"one\", [true] = \"]]\\n\"}\n",
+		},
+		{
+			Name:    "LongStrings",
+			Input:   values.Array{values.String(`C:\path\to\"file"`), values.String("\nx]="), values.String("tab\t"), values.String(`]]""""""`)},
+			Factory: Lua{Format: json.OneLine, LongStrings: true},
+			Expect:  "{[[C:\\path\\to\\\"file\"]], \"\\nx]=\", \"tab\\t\", [=[]]\"\"\"\"\"\"]=]}\n",
+		},
+		{
+			Name:    "LongStrings/ObjectKey",
+			Input:   values.Object{{Key: `a"b"c\d`, Value: values.Int(1)}},
+			Factory: Lua{Format: json.OneLine, LongStrings: true},
+			Expect:  "{[\"a\\\"b\\\"c\\\\d\"] = 1}\n",
+		},
+		{
+			Name: "LongStrings/Keys",
+			Input: values.Map{
+				{Key: values.String(`a"b"c\d`), Value: values.Int(1)},
+				{Key: values.String(`x\y\z"`), Value: values.String(`x\y\z"`)},
+			},
+			Factory: Lua{Format: json.OneLine, LongStrings: true},
+			Expect:  "{[\"a\\\"b\\\"c\\\\d\"] = 1, [\"x\\\\y\\\\z\\\"\"] = [[x\\y\\z\"]]}\n",
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, row.Factory.NewGenerator())
+			row.Input.EmitTo(&e)
+			err := e.Close()
+
+			if !reflect.DeepEqual(err, row.ExpectErr) {
+				t.Errorf("wrong error:\n\texpect: %#v\n\tactual: %#v", row.ExpectErr, err)
+				return
+			}
+
+			if actual := buf.String(); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
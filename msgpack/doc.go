@@ -0,0 +1,2 @@
+// Package msgpack implements the MessagePack format for Emitter.
+package msgpack
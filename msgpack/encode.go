@@ -0,0 +1,164 @@
+package msgpack
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+)
+
+const (
+	codeNil      = 0xc0
+	codeFalse    = 0xc2
+	codeTrue     = 0xc3
+	codeBin8     = 0xc4
+	codeBin16    = 0xc5
+	codeBin32    = 0xc6
+	codeExt8     = 0xc7
+	codeExt16    = 0xc8
+	codeExt32    = 0xc9
+	codeFloat32  = 0xca
+	codeFloat64  = 0xcb
+	codeUint8    = 0xcc
+	codeUint16   = 0xcd
+	codeUint32   = 0xce
+	codeUint64   = 0xcf
+	codeInt8     = 0xd0
+	codeInt16    = 0xd1
+	codeInt32    = 0xd2
+	codeInt64    = 0xd3
+	codeFixExt1  = 0xd4
+	codeStr8     = 0xd9
+	codeStr16    = 0xda
+	codeStr32    = 0xdb
+	codeArray16  = 0xdc
+	codeArray32  = 0xdd
+	codeMap16    = 0xde
+	codeMap32    = 0xdf
+	codeFixMap   = 0x80
+	codeFixArray = 0x90
+	codeFixStr   = 0xa0
+)
+
+const extTimestamp = -1
+
+func appendUint(out []byte, value uint64) []byte {
+	switch {
+	case value < 0x80:
+		return append(out, byte(value))
+	case value <= math.MaxUint8:
+		return append(out, codeUint8, byte(value))
+	case value <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(out, codeUint16), uint16(value))
+	case value <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(out, codeUint32), uint32(value))
+	default:
+		return binary.BigEndian.AppendUint64(append(out, codeUint64), value)
+	}
+}
+
+func appendInt(out []byte, value int64) []byte {
+	switch {
+	case value >= 0:
+		return appendUint(out, uint64(value))
+	case value >= -32:
+		return append(out, byte(value))
+	case value >= math.MinInt8:
+		return append(out, codeInt8, byte(value))
+	case value >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(out, codeInt16), uint16(value))
+	case value >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(out, codeInt32), uint32(value))
+	default:
+		return binary.BigEndian.AppendUint64(append(out, codeInt64), uint64(value))
+	}
+}
+
+func appendFloat(out []byte, value float64) []byte {
+	if f32 := float32(value); float64(f32) == value || math.IsNaN(value) {
+		return binary.BigEndian.AppendUint32(append(out, codeFloat32), math.Float32bits(f32))
+	}
+	return binary.BigEndian.AppendUint64(append(out, codeFloat64), math.Float64bits(value))
+}
+
+func appendString(out []byte, str string) []byte {
+	n := uint64(len(str))
+	switch {
+	case n < 32:
+		out = append(out, codeFixStr|byte(n))
+	case n <= math.MaxUint8:
+		out = append(out, codeStr8, byte(n))
+	case n <= math.MaxUint16:
+		out = binary.BigEndian.AppendUint16(append(out, codeStr16), uint16(n))
+	default:
+		out = binary.BigEndian.AppendUint32(append(out, codeStr32), uint32(n))
+	}
+	return append(out, str...)
+}
+
+func appendBinary(out []byte, data []byte) []byte {
+	n := uint64(len(data))
+	switch {
+	case n <= math.MaxUint8:
+		out = append(out, codeBin8, byte(n))
+	case n <= math.MaxUint16:
+		out = binary.BigEndian.AppendUint16(append(out, codeBin16), uint16(n))
+	default:
+		out = binary.BigEndian.AppendUint32(append(out, codeBin32), uint32(n))
+	}
+	return append(out, data...)
+}
+
+func appendExt(out []byte, extType int8, data []byte) []byte {
+	n := uint64(len(data))
+	switch n {
+	case 1, 2, 4, 8, 16:
+		code := byte(codeFixExt1)
+		for size := uint64(1); size < n; size <<= 1 {
+			code++
+		}
+		out = append(out, code)
+	default:
+		switch {
+		case n <= math.MaxUint8:
+			out = append(out, codeExt8, byte(n))
+		case n <= math.MaxUint16:
+			out = binary.BigEndian.AppendUint16(append(out, codeExt16), uint16(n))
+		default:
+			out = binary.BigEndian.AppendUint32(append(out, codeExt32), uint32(n))
+		}
+	}
+	out = append(out, byte(extType))
+	return append(out, data...)
+}
+
+func appendContainerHeader(out []byte, isMap bool, n uint64) []byte {
+	fix, code16, code32 := byte(codeFixArray), byte(codeArray16), byte(codeArray32)
+	if isMap {
+		fix, code16, code32 = codeFixMap, codeMap16, codeMap32
+	}
+	switch {
+	case n < 16:
+		return append(out, fix|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(out, code16), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(out, code32), uint32(n))
+	}
+}
+
+func appendTimestamp(out []byte, value time.Time) []byte {
+	sec := value.Unix()
+	nsec := uint64(value.Nanosecond())
+	switch {
+	case sec >= 0 && sec <= math.MaxUint32 && nsec == 0:
+		data := binary.BigEndian.AppendUint32(nil, uint32(sec))
+		return appendExt(out, extTimestamp, data)
+	case sec >= 0 && sec < 1<<34:
+		data := binary.BigEndian.AppendUint64(nil, nsec<<34|uint64(sec))
+		return appendExt(out, extTimestamp, data)
+	default:
+		data := binary.BigEndian.AppendUint32(nil, uint32(nsec))
+		data = binary.BigEndian.AppendUint64(data, uint64(sec))
+		return appendExt(out, extTimestamp, data)
+	}
+}
@@ -0,0 +1,286 @@
+package msgpack
+
+import (
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+type Appender = appenders.Appender
+
+type frame struct {
+	isMap  bool
+	count  uint64
+	header int
+}
+
+type header struct {
+	pos  int
+	data []byte
+}
+
+type Generator struct {
+	msgpack MsgPack
+	sm      states.Machine
+	frames  []frame
+	buf     []byte
+	headers []header
+}
+
+func (g *Generator) Reset() {
+	g.sm.Reset()
+	g.frames = g.frames[:0]
+	g.buf = g.buf[:0]
+	g.headers = g.headers[:0]
+}
+
+func (g *Generator) Factory() emitter.GeneratorFactory {
+	return g.msgpack
+}
+
+func (g *Generator) Begin() []Appender {
+	g.sm.ExpectRoot()
+	return nil
+}
+
+func (g *Generator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+	return nil
+}
+
+func (g *Generator) NextDocument() []Appender {
+	g.sm.NextDocument()
+	return nil
+}
+
+func (g *Generator) StartObject() []Appender {
+	return g.startContainer(true, states.ObjectFirstKey)
+}
+
+func (g *Generator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	return g.endContainer()
+}
+
+func (g *Generator) StartArray() []Appender {
+	return g.startContainer(false, states.ArrayFirstValue)
+}
+
+func (g *Generator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	return g.endContainer()
+}
+
+func (g *Generator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+	g.top().count++
+	list := g.write(appendString(nil, key))
+	g.sm.Next()
+	return list
+}
+
+func (g *Generator) StartKey() []Appender {
+	g.sm.StartKey()
+	g.top().count++
+	return nil
+}
+
+func (g *Generator) EndKey() []Appender {
+	g.sm.EndKey()
+	return nil
+}
+
+func (g *Generator) NullValue() []Appender {
+	return g.value([]byte{codeNil})
+}
+
+func (g *Generator) BoolValue(value bool) []Appender {
+	if value {
+		return g.value([]byte{codeTrue})
+	}
+	return g.value([]byte{codeFalse})
+}
+
+func (g *Generator) IntValue(value int64) []Appender {
+	return g.value(appendInt(nil, value))
+}
+
+func (g *Generator) UintValue(value uint64) []Appender {
+	return g.value(appendUint(nil, value))
+}
+
+func (g *Generator) BigIntValue(value *big.Int) []Appender {
+	switch {
+	case value == nil:
+		return g.NullValue()
+	case value.IsInt64():
+		return g.IntValue(value.Int64())
+	case value.IsUint64():
+		return g.UintValue(value.Uint64())
+	}
+
+	switch g.msgpack.BigNumbers {
+	case BigAsFloat:
+		f, _ := new(big.Float).SetInt(value).Float64()
+		return g.FloatValue(f)
+	case BigAsExtension:
+		return g.value(appendExt(nil, g.msgpack.BigIntExtType, appenders.TwosComplement(value)))
+	default:
+		return g.StringValue(value.String())
+	}
+}
+
+func (g *Generator) NaNValue() []Appender {
+	return g.FloatValue(math.NaN())
+}
+
+func (g *Generator) InfValue(isNeg bool) []Appender {
+	if isNeg {
+		return g.FloatValue(math.Inf(-1))
+	}
+	return g.FloatValue(math.Inf(1))
+}
+
+func (g *Generator) FloatValue(value float64) []Appender {
+	return g.value(appendFloat(nil, value))
+}
+
+func (g *Generator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+
+	f, acc := value.Float64()
+	if acc == big.Exact || g.msgpack.BigNumbers == BigAsFloat {
+		return g.FloatValue(f)
+	}
+	return g.StringValue(value.Text('g', -1))
+}
+
+func (g *Generator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if value.IsInt() {
+		return g.BigIntValue(value.Num())
+	}
+
+	f, exact := value.Float64()
+	if exact || g.msgpack.BigNumbers == BigAsFloat {
+		return g.FloatValue(f)
+	}
+	return g.StringValue(value.String())
+}
+
+func (g *Generator) ComplexValue(value complex128) []Appender {
+	var out []byte
+	out = appendContainerHeader(out, false, 2)
+	out = appendFloat(out, real(value))
+	out = appendFloat(out, imag(value))
+	return g.value(out)
+}
+
+func (g *Generator) StringValue(value string) []Appender {
+	return g.value(appendString(nil, value))
+}
+
+func (g *Generator) BytesValue(value []byte) []Appender {
+	return g.value(appendBinary(nil, value))
+}
+
+func (g *Generator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *Generator) RuneValue(value rune) []Appender {
+	return g.StringValue(string(value))
+}
+
+func (g *Generator) TimeValue(value time.Time) []Appender {
+	return g.value(appendTimestamp(nil, value))
+}
+
+func (g *Generator) startContainer(isMap bool, next states.State) []Appender {
+	g.beginValue()
+	g.sm.Push(next)
+	g.headers = append(g.headers, header{pos: len(g.buf)})
+	g.frames = append(g.frames, frame{isMap: isMap, header: len(g.headers) - 1})
+	return nil
+}
+
+func (g *Generator) endContainer() []Appender {
+	n := len(g.frames) - 1
+	f := g.frames[n]
+	g.frames = g.frames[:n]
+	g.sm.Pop()
+	g.sm.Next()
+
+	g.headers[f.header].data = appendContainerHeader(nil, f.isMap, f.count)
+	if n > 0 {
+		return nil
+	}
+
+	return []Appender{appenders.LiteralBytes(g.splice())}
+}
+
+// splice merges the buffered container bodies with their headers.  Header
+// slots are reserved when each container opens, so they are already in
+// output order.
+func (g *Generator) splice() []byte {
+	headers := g.headers
+
+	size := len(g.buf)
+	for _, h := range headers {
+		size += len(h.data)
+	}
+
+	out := make([]byte, 0, size)
+	last := 0
+	for _, h := range headers {
+		out = append(out, g.buf[last:h.pos]...)
+		out = append(out, h.data...)
+		last = h.pos
+	}
+	out = append(out, g.buf[last:]...)
+
+	g.buf = g.buf[:0]
+	g.headers = g.headers[:0]
+	return out
+}
+
+func (g *Generator) beginValue() {
+	g.sm.ExpectValue()
+	if g.sm.State.In(states.ArrayFirstValue, states.ArrayNextValue) {
+		g.top().count++
+	}
+}
+
+func (g *Generator) value(data []byte) []Appender {
+	g.beginValue()
+	list := g.write(data)
+	g.sm.Next()
+	return list
+}
+
+func (g *Generator) write(data []byte) []Appender {
+	if len(g.frames) > 0 {
+		g.buf = append(g.buf, data...)
+		return nil
+	}
+	return []Appender{appenders.LiteralBytes(data)}
+}
+
+func (g *Generator) top() *frame {
+	return &g.frames[len(g.frames)-1]
+}
+
+var (
+	_ emitter.Generator       = (*Generator)(nil)
+	_ emitter.KeyGenerator    = (*Generator)(nil)
+	_ emitter.StreamGenerator = (*Generator)(nil)
+)
@@ -0,0 +1,91 @@
+package msgpack
+
+import (
+	"encoding"
+	"fmt"
+
+	"github.com/chronos-tachyon/go-emitter"
+)
+
+// MsgPack generates MessagePack.  Since MessagePack needs the element count
+// of each array and map up front, the Generator buffers every top-level
+// container in full and splices the headers in once it ends; top-level
+// scalars are written immediately.
+type MsgPack struct {
+	BigNumbers    BigNumberStrategy
+	BigIntExtType int8
+}
+
+func (msgpack MsgPack) NewGenerator() emitter.Generator {
+	g := &Generator{msgpack: msgpack}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = MsgPack{}
+
+type BigNumberStrategy byte
+
+const (
+	BigAsString BigNumberStrategy = iota
+	BigAsFloat
+	BigAsExtension
+)
+
+const bigNumberStrategySize = 3
+
+var bigNumberStrategyGoNames = [bigNumberStrategySize]string{
+	"msgpack.BigAsString",
+	"msgpack.BigAsFloat",
+	"msgpack.BigAsExtension",
+}
+
+var bigNumberStrategyNames = [bigNumberStrategySize]string{
+	"string",
+	"float",
+	"extension",
+}
+
+func (s BigNumberStrategy) IsValid() bool {
+	return s < bigNumberStrategySize
+}
+
+func (s BigNumberStrategy) GoString() string {
+	if s.IsValid() {
+		return bigNumberStrategyGoNames[s]
+	}
+	return fmt.Sprintf("msgpack.BigNumberStrategy(%d)", uint(s))
+}
+
+func (s BigNumberStrategy) String() string {
+	if s.IsValid() {
+		return bigNumberStrategyNames[s]
+	}
+	return fmt.Sprintf("%%!ERR[invalid msgpack.BigNumberStrategy %d]", uint(s))
+}
+
+func (s BigNumberStrategy) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *BigNumberStrategy) Parse(input string) error {
+	for index, name := range bigNumberStrategyNames {
+		if input == name {
+			*s = BigNumberStrategy(index)
+			return nil
+		}
+	}
+	*s = ^BigNumberStrategy(0)
+	return fmt.Errorf("failed to parse %q as msgpack.BigNumberStrategy", input)
+}
+
+func (s *BigNumberStrategy) UnmarshalText(input []byte) error {
+	return s.Parse(string(input))
+}
+
+var (
+	_ fmt.GoStringer           = BigNumberStrategy(0)
+	_ fmt.Stringer             = BigNumberStrategy(0)
+	_ encoding.TextMarshaler   = BigNumberStrategy(0)
+	_ encoding.TextUnmarshaler = (*BigNumberStrategy)(nil)
+)
@@ -0,0 +1,127 @@
+package msgpack
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+func bigInt(str string) Value {
+	x, ok := new(big.Int).SetString(str, 10)
+	if !ok {
+		panic(str)
+	}
+	return values.BigIntValue{Pointer: x}
+}
+
+func TestMsgPack(t *testing.T) {
+	type testCase struct {
+		Name    string
+		Input   Value
+		Factory emitter.GeneratorFactory
+		Expect  string
+	}
+
+	plain := MsgPack{}
+
+	sixteen := make(values.Array, 16)
+	for index := range sixteen {
+		sixteen[index] = values.Int(index)
+	}
+
+	testData := [...]testCase{
+		{Name: "Null", Input: values.Null{}, Factory: plain, Expect: "c0"},
+		{Name: "False", Input: values.Bool(false), Factory: plain, Expect: "c2"},
+		{Name: "True", Input: values.Bool(true), Factory: plain, Expect: "c3"},
+		{Name: "Int/0", Input: values.Int(0), Factory: plain, Expect: "00"},
+		{Name: "Int/127", Input: values.Int(127), Factory: plain, Expect: "7f"},
+		{Name: "Int/128", Input: values.Int(128), Factory: plain, Expect: "cc80"},
+		{Name: "Int/65536", Input: values.Int(65536), Factory: plain, Expect: "ce00010000"},
+		{Name: "Int/-1", Input: values.Int(-1), Factory: plain, Expect: "ff"},
+		{Name: "Int/-32", Input: values.Int(-32), Factory: plain, Expect: "e0"},
+		{Name: "Int/-33", Input: values.Int(-33), Factory: plain, Expect: "d0df"},
+		{Name: "Int/-129", Input: values.Int(-129), Factory: plain, Expect: "d1ff7f"},
+		{Name: "Int/Min", Input: values.Int(math.MinInt64), Factory: plain, Expect: "d38000000000000000"},
+		{Name: "Uint/Max", Input: values.Uint(math.MaxUint64), Factory: plain, Expect: "cfffffffffffffffff"},
+		{Name: "Float/1.5", Input: values.Float(1.5), Factory: plain, Expect: "ca3fc00000"},
+		{Name: "Float/1.1", Input: values.Float(1.1), Factory: plain, Expect: "cb3ff199999999999a"},
+		{Name: "Float/Inf", Input: values.Float(math.Inf(1)), Factory: plain, Expect: "ca7f800000"},
+		{Name: "String/Short", Input: values.String("abc"), Factory: plain, Expect: "a3616263"},
+		{Name: "String/32", Input: values.String(strings.Repeat("x", 32)), Factory: plain, Expect: "d920" + strings.Repeat("78", 32)},
+		{Name: "Bytes", Input: values.Bytes{1, 2, 3}, Factory: plain, Expect: "c403010203"},
+		{Name: "Time/32", Input: values.Time(time.Unix(1, 0)), Factory: plain, Expect: "d6ff00000001"},
+		{Name: "Time/64", Input: values.Time(time.Unix(1, 1)), Factory: plain, Expect: "d7ff0000000400000001"},
+		{Name: "Time/96", Input: values.Time(time.Unix(-1, 0)), Factory: plain, Expect: "c70cff00000000ffffffffffffffff"},
+		{Name: "BigInt/Small", Input: bigInt("-5"), Factory: plain, Expect: "fb"},
+		{Name: "BigInt/String", Input: bigInt("18446744073709551616"), Factory: plain, Expect: "b4" + hex.EncodeToString([]byte("18446744073709551616"))},
+		{Name: "BigInt/Float", Input: bigInt("18446744073709551616"), Factory: MsgPack{BigNumbers: BigAsFloat}, Expect: "ca5f800000"},
+		{Name: "BigInt/Extension", Input: bigInt("18446744073709551616"), Factory: MsgPack{BigNumbers: BigAsExtension, BigIntExtType: 7}, Expect: "c70907010000000000000000"},
+		{Name: "BigInt/NegativeExtension", Input: bigInt("-9223372036854775809"), Factory: MsgPack{BigNumbers: BigAsExtension, BigIntExtType: 7}, Expect: "c70907ff7fffffffffffffff"},
+		{Name: "Rat/Exact", Input: values.BigRatValue{Pointer: big.NewRat(1, 4)}, Factory: plain, Expect: "ca3e800000"},
+		{Name: "Rat/String", Input: values.BigRatValue{Pointer: big.NewRat(1, 3)}, Factory: plain, Expect: "a3312f33"},
+		{Name: "Complex", Input: values.Complex(complex(1, 2)), Factory: plain, Expect: "92ca3f800000ca40000000"},
+		{
+			Name:    "Array/Nested",
+			Input:   values.Array{values.Array{values.Array{values.Int(1)}}, values.Array(nil), values.Int(2)},
+			Factory: plain,
+			Expect:  "93919101" + "90" + "02",
+		},
+		{
+			Name:    "Array/NestedEmpty",
+			Input:   values.Array{values.Array{values.Array(nil)}, values.Object(nil)},
+			Factory: plain,
+			Expect:  "92" + "9190" + "80",
+		},
+		{
+			Name:    "Array/16",
+			Input:   values.Array{sixteen},
+			Factory: plain,
+			Expect:  "91dc0010000102030405060708090a0b0c0d0e0f",
+		},
+		{
+			Name: "Map",
+			Input: values.Object{
+				{Key: "a", Value: values.Int(1)},
+				{Key: "b", Value: values.Object{{Key: "c", Value: values.Null{}}}},
+			},
+			Factory: plain,
+			Expect:  "82a16101a16281a163c0",
+		},
+		{
+			Name: "Map/NonStringKeys",
+			Input: values.Map{
+				{Key: values.Int(1), Value: values.Bool(true)},
+				{Key: values.Array{values.Int(2)}, Value: values.Bool(false)},
+			},
+			Factory: plain,
+			Expect:  "8201c39102c2",
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, row.Factory.NewGenerator())
+			row.Input.EmitTo(&e)
+			if err := e.Close(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if actual := hex.EncodeToString(buf.Bytes()); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %s\n\tactual: %s", row.Expect, actual)
+			}
+		})
+	}
+}
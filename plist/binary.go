@@ -0,0 +1,280 @@
+package plist
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+type Appender = appenders.Appender
+
+// object is an entry in the object table.  Scalars are encoded as soon as
+// they are seen; containers hold the indices of their members until the
+// table is written, since the width of those indices is not yet known.
+type object struct {
+	data   []byte
+	marker byte
+	count  uint64
+	refs   []uint64
+}
+
+type frame struct {
+	index  uint64
+	keys   []uint64
+	values []uint64
+}
+
+type BinaryGenerator struct {
+	binary  Binary
+	sm      states.Machine
+	objects []object
+	unique  map[string]uint64
+	frames  []frame
+}
+
+func (g *BinaryGenerator) Reset() {
+	g.sm.Reset()
+	g.objects = g.objects[:0]
+	g.unique = make(map[string]uint64)
+	g.frames = g.frames[:0]
+}
+
+func (g *BinaryGenerator) Factory() emitter.GeneratorFactory {
+	return g.binary
+}
+
+func (g *BinaryGenerator) Begin() []Appender {
+	g.sm.ExpectRoot()
+	return nil
+}
+
+func (g *BinaryGenerator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+	return nil
+}
+
+func (g *BinaryGenerator) StartObject() []Appender {
+	return g.startContainer(markerDict, states.ObjectFirstKey)
+}
+
+func (g *BinaryGenerator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	return g.endContainer()
+}
+
+func (g *BinaryGenerator) StartArray() []Appender {
+	return g.startContainer(markerArray, states.ArrayFirstValue)
+}
+
+func (g *BinaryGenerator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	return g.endContainer()
+}
+
+func (g *BinaryGenerator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+	f := g.top()
+	f.keys = append(f.keys, g.add(appendString(nil, key)))
+	g.sm.Next()
+	return nil
+}
+
+func (g *BinaryGenerator) NullValue() []Appender {
+	g.sm.ExpectValue()
+	g.sm.Next()
+	return g.fail(fmt.Errorf("plist does not support null"))
+}
+
+func (g *BinaryGenerator) BoolValue(value bool) []Appender {
+	if value {
+		return g.value([]byte{markerTrue})
+	}
+	return g.value([]byte{markerFalse})
+}
+
+func (g *BinaryGenerator) IntValue(value int64) []Appender {
+	return g.value(appendInt(nil, value))
+}
+
+func (g *BinaryGenerator) UintValue(value uint64) []Appender {
+	return g.value(appendUint(nil, value))
+}
+
+func (g *BinaryGenerator) BigIntValue(value *big.Int) []Appender {
+	switch {
+	case value == nil:
+		return g.NullValue()
+	case value.IsInt64():
+		return g.IntValue(value.Int64())
+	case value.IsUint64():
+		return g.UintValue(value.Uint64())
+	default:
+		g.sm.ExpectValue()
+		g.sm.Next()
+		return g.fail(fmt.Errorf("plist integer %v does not fit in 64 bits", value))
+	}
+}
+
+func (g *BinaryGenerator) NaNValue() []Appender {
+	return g.FloatValue(math.NaN())
+}
+
+func (g *BinaryGenerator) InfValue(isNeg bool) []Appender {
+	if isNeg {
+		return g.FloatValue(math.Inf(-1))
+	}
+	return g.FloatValue(math.Inf(1))
+}
+
+func (g *BinaryGenerator) FloatValue(value float64) []Appender {
+	return g.value(appendReal(nil, value))
+}
+
+func (g *BinaryGenerator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	f, _ := value.Float64()
+	return g.FloatValue(f)
+}
+
+func (g *BinaryGenerator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	f, _ := value.Float64()
+	return g.FloatValue(f)
+}
+
+func (g *BinaryGenerator) ComplexValue(value complex128) []Appender {
+	var list []Appender
+	list = append(list, g.StartObject()...)
+	list = append(list, g.Key("re")...)
+	list = append(list, g.FloatValue(real(value))...)
+	list = append(list, g.Key("im")...)
+	list = append(list, g.FloatValue(imag(value))...)
+	list = append(list, g.EndObject()...)
+	return list
+}
+
+func (g *BinaryGenerator) StringValue(value string) []Appender {
+	return g.value(appendString(nil, value))
+}
+
+func (g *BinaryGenerator) BytesValue(value []byte) []Appender {
+	return g.value(appendData(nil, value))
+}
+
+func (g *BinaryGenerator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *BinaryGenerator) RuneValue(value rune) []Appender {
+	return g.StringValue(string(value))
+}
+
+func (g *BinaryGenerator) TimeValue(value time.Time) []Appender {
+	return g.value(appendDate(nil, value))
+}
+
+// add returns the index of the scalar encoded as data, sharing the entry of
+// an identical scalar if there is one.
+func (g *BinaryGenerator) add(data []byte) uint64 {
+	if index, found := g.unique[string(data)]; found {
+		return index
+	}
+	index := uint64(len(g.objects))
+	g.objects = append(g.objects, object{data: data})
+	g.unique[string(data)] = index
+	return index
+}
+
+func (g *BinaryGenerator) startContainer(marker byte, next states.State) []Appender {
+	g.sm.ExpectValue()
+	index := uint64(len(g.objects))
+	g.objects = append(g.objects, object{marker: marker})
+	if len(g.frames) > 0 {
+		f := g.top()
+		f.values = append(f.values, index)
+	}
+	g.frames = append(g.frames, frame{index: index})
+	g.sm.Push(next)
+	return nil
+}
+
+func (g *BinaryGenerator) endContainer() []Appender {
+	n := len(g.frames) - 1
+	f := g.frames[n]
+	g.frames = g.frames[:n]
+	g.sm.Pop()
+
+	obj := &g.objects[f.index]
+	obj.count = uint64(len(f.values))
+	obj.refs = append(f.keys, f.values...)
+	return g.finish(f.index)
+}
+
+func (g *BinaryGenerator) value(data []byte) []Appender {
+	g.sm.ExpectValue()
+	index := g.add(data)
+	if len(g.frames) > 0 {
+		f := g.top()
+		f.values = append(f.values, index)
+	}
+	return g.finish(index)
+}
+
+// finish advances past a completed value and, if it was the top-level
+// value, writes out the whole property list.
+func (g *BinaryGenerator) finish(index uint64) []Appender {
+	g.sm.Next()
+	if len(g.frames) > 0 {
+		return nil
+	}
+
+	refSize := sizeFor(uint64(len(g.objects) - 1))
+	offsets := make([]uint64, len(g.objects))
+	out := append([]byte(nil), magic...)
+	for i, obj := range g.objects {
+		offsets[i] = uint64(len(out))
+		if obj.data != nil {
+			out = append(out, obj.data...)
+			continue
+		}
+		out = appendMarker(out, obj.marker, obj.count)
+		for _, ref := range obj.refs {
+			out = appendSized(out, ref, refSize)
+		}
+	}
+
+	tableOffset := uint64(len(out))
+	offsetSize := sizeFor(offsets[len(offsets)-1])
+	for _, offset := range offsets {
+		out = appendSized(out, offset, offsetSize)
+	}
+
+	out = append(out, 0, 0, 0, 0, 0, 0, byte(offsetSize), byte(refSize))
+	out = appendSized(out, uint64(len(g.objects)), 8)
+	out = appendSized(out, index, 8)
+	out = appendSized(out, tableOffset, 8)
+
+	g.objects = g.objects[:0]
+	clear(g.unique)
+	return []Appender{appenders.LiteralBytes(out)}
+}
+
+func (g *BinaryGenerator) fail(err error) []Appender {
+	return []Appender{appenders.Error{Err: err}}
+}
+
+func (g *BinaryGenerator) top() *frame {
+	return &g.frames[len(g.frames)-1]
+}
+
+var _ emitter.Generator = (*BinaryGenerator)(nil)
@@ -0,0 +1,3 @@
+// Package plist implements Apple property lists, in both the XML format and
+// the "bplist00" binary format, for Emitter.
+package plist
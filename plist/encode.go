@@ -0,0 +1,117 @@
+package plist
+
+import (
+	"math"
+	"time"
+	"unicode/utf16"
+)
+
+const (
+	markerFalse   = 0x08
+	markerTrue    = 0x09
+	markerInt     = 0x10
+	markerReal    = 0x20
+	markerDate    = 0x33
+	markerData    = 0x40
+	markerASCII   = 0x50
+	markerUnicode = 0x60
+	markerArray   = 0xa0
+	markerDict    = 0xd0
+
+	countInline = 0x0f
+)
+
+var magic = []byte("bplist00")
+
+// referenceDate is the epoch of binary plist dates, which are stored as
+// floating-point seconds relative to it.
+var referenceDate = time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// appendInt uses the unsigned 1-, 2-, and 4-byte forms where possible;
+// the 8-byte form is signed.
+func appendInt(out []byte, value int64) []byte {
+	switch {
+	case value < 0 || value > math.MaxUint32:
+		return appendSized(append(out, markerInt|3), uint64(value), 8)
+	case value > math.MaxUint16:
+		return appendSized(append(out, markerInt|2), uint64(value), 4)
+	case value > math.MaxUint8:
+		return appendSized(append(out, markerInt|1), uint64(value), 2)
+	default:
+		return append(out, markerInt, byte(value))
+	}
+}
+
+// appendUint uses the 16-byte form for values that do not fit in the signed
+// 8-byte form.
+func appendUint(out []byte, value uint64) []byte {
+	if value <= math.MaxInt64 {
+		return appendInt(out, int64(value))
+	}
+	out = append(out, markerInt|4, 0, 0, 0, 0, 0, 0, 0, 0)
+	return appendSized(out, value, 8)
+}
+
+func appendReal(out []byte, value float64) []byte {
+	return appendSized(append(out, markerReal|3), math.Float64bits(value), 8)
+}
+
+func appendDate(out []byte, value time.Time) []byte {
+	seconds := float64(value.Unix()-referenceDate.Unix()) + float64(value.Nanosecond())/1e9
+	return appendSized(append(out, markerDate), math.Float64bits(seconds), 8)
+}
+
+func appendData(out []byte, value []byte) []byte {
+	out = appendMarker(out, markerData, uint64(len(value)))
+	return append(out, value...)
+}
+
+// appendString uses the ASCII form if it can, and UTF-16 otherwise.
+func appendString(out []byte, value string) []byte {
+	isASCII := true
+	for i := 0; i < len(value); i++ {
+		isASCII = isASCII && value[i] < 0x80
+	}
+	if isASCII {
+		out = appendMarker(out, markerASCII, uint64(len(value)))
+		return append(out, value...)
+	}
+
+	units := utf16.Encode([]rune(value))
+	out = appendMarker(out, markerUnicode, uint64(len(units)))
+	for _, unit := range units {
+		out = append(out, byte(unit>>8), byte(unit))
+	}
+	return out
+}
+
+// appendMarker writes an object marker whose low nibble is a count, or 0xF
+// followed by an integer object if the count does not fit.
+func appendMarker(out []byte, marker byte, count uint64) []byte {
+	if count < countInline {
+		return append(out, marker|byte(count))
+	}
+	return appendUint(append(out, marker|countInline), count)
+}
+
+func appendSized(out []byte, value uint64, size int) []byte {
+	for i := size - 1; i >= 0; i-- {
+		out = append(out, byte(value>>(8*i)))
+	}
+	return out
+}
+
+// sizeFor returns the width used for object references and offsets that
+// are at most max.
+func sizeFor(max uint64) int {
+	switch {
+	case max <= math.MaxUint8:
+		return 1
+	case max <= math.MaxUint16:
+		return 2
+	case max <= math.MaxUint32:
+		return 4
+	default:
+		return 8
+	}
+}
@@ -0,0 +1,33 @@
+package plist
+
+import (
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/json"
+)
+
+// XML generates XML property lists, complete with the XML declaration,
+// DOCTYPE, and <plist> element.  Use json.MultiLine with IndentWithTabs to
+// match the files written by Apple's tools.
+type XML struct {
+	Format         json.Format
+	IndentSize     uint
+	IndentWithTabs bool
+}
+
+func (xml XML) NewGenerator() emitter.Generator {
+	g := &XMLGenerator{xml: xml}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = XML{}
+
+type Binary struct{}
+
+func (binary Binary) NewGenerator() emitter.Generator {
+	g := &BinaryGenerator{binary: binary}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = Binary{}
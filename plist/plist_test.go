@@ -0,0 +1,140 @@
+package plist
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/json"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+const xmlHeader = xmlDeclaration + "\n" + xmlDoctype + "\n" + `<plist version="1.0">`
+
+func TestXML(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Input     Value
+		Factory   emitter.GeneratorFactory
+		Expect    string
+		ExpectErr error
+	}
+
+	compact := XML{}
+	oneLine := XML{Format: json.OneLine}
+	multiLine := XML{Format: json.MultiLine, IndentWithTabs: true}
+
+	testData := [...]testCase{
+		{Name: "Null", Input: values.Null{}, Factory: compact, ExpectErr: fmt.Errorf("plist does not support null")},
+		{Name: "Null/Nested", Input: values.Array{values.Int(1), values.Null{}}, Factory: compact, ExpectErr: fmt.Errorf("plist does not support null")},
+		{Name: "Bool", Input: values.Bool(false), Factory: oneLine, Expect: xmlHeader + " <false/> </plist>\n"},
+		{Name: "Int", Input: values.Int(-42), Factory: oneLine, Expect: xmlHeader + " <integer>-42</integer> </plist>\n"},
+		{Name: "Float", Input: values.Float(0.25), Factory: oneLine, Expect: xmlHeader + " <real>0.25</real> </plist>\n"},
+		{Name: "Float/Inf", Input: values.Float(math.Inf(-1)), Factory: oneLine, Expect: xmlHeader + " <real>-infinity</real> </plist>\n"},
+		{Name: "String", Input: values.String("a < b & c"), Factory: oneLine, Expect: xmlHeader + " <string>a &lt; b &amp; c</string> </plist>\n"},
+		{Name: "Bytes", Input: values.Bytes("hello"), Factory: oneLine, Expect: xmlHeader + " <data>aGVsbG8=</data> </plist>\n"},
+		{Name: "Time", Input: values.Time(time.Date(2020, 1, 2, 3, 4, 5, 600000000, time.FixedZone("", 3600))), Factory: oneLine, Expect: xmlHeader + " <date>2020-01-02T02:04:05Z</date> </plist>\n"},
+		{Name: "Empty", Input: values.Array{values.Object(nil), values.Array(nil)}, Factory: compact, Expect: "<?xml version=\"1.0\" encoding=\"UTF-8\"?><!DOCTYPE plist PUBLIC \"-//Apple//DTD PLIST 1.0//EN\" \"http://www.apple.com/DTDs/PropertyList-1.0.dtd\"><plist version=\"1.0\"><array><dict/><array/></array></plist>"},
+		{
+			Name: "Dict",
+			Input: values.Object{
+				{Key: "CFBundleName", Value: values.String("Demo")},
+				{Key: "LSRequiresIPhoneOS", Value: values.Bool(true)},
+				{Key: "UIRequiredDeviceCapabilities", Value: values.Array{values.String("arm64")}},
+			},
+			Factory: multiLine,
+			Expect:  xmlHeader + "\n<dict>\n\t<key>CFBundleName</key>\n\t<string>Demo</string>\n\t<key>LSRequiresIPhoneOS</key>\n\t<true/>\n\t<key>UIRequiredDeviceCapabilities</key>\n\t<array>\n\t\t<string>arm64</string>\n\t</array>\n</dict>\n</plist>\n",
+		},
+		{
+			Name:    "Dict/OneLine",
+			Input:   values.Object{{Key: "a", Value: values.Int(1)}, {Key: "b", Value: values.Complex(complex(1, 2))}},
+			Factory: oneLine,
+			Expect:  xmlHeader + " <dict> <key>a</key> <integer>1</integer> <key>b</key> <dict> <key>re</key> <real>1</real> <key>im</key> <real>2</real> </dict> </dict> </plist>\n",
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, row.Factory.NewGenerator())
+			row.Input.EmitTo(&e)
+			err := e.Close()
+
+			if !reflect.DeepEqual(err, row.ExpectErr) {
+				t.Errorf("wrong error:\n\texpect: %#v\n\tactual: %#v", row.ExpectErr, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+
+			if actual := buf.String(); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func TestBinary(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Input     Value
+		Expect    string
+		ExpectErr error
+	}
+
+	const magicHex = "62706c6973743030"
+
+	testData := [...]testCase{
+		{Name: "Null", Input: values.Null{}, ExpectErr: fmt.Errorf("plist does not support null")},
+		{Name: "True", Input: values.Bool(true), Expect: magicHex + "09080000000000000101000000000000000100000000000000000000000000000009"},
+		{Name: "Int/300", Input: values.Int(300), Expect: magicHex + "11012c08000000000000010100000000000000010000000000000000000000000000000b"},
+		{Name: "Int/-1", Input: values.Int(-1), Expect: magicHex + "13ffffffffffffffff080000000000000101000000000000000100000000000000000000000000000011"},
+		{Name: "Uint/Max", Input: values.Uint(math.MaxUint64), Expect: magicHex + "140000000000000000ffffffffffffffff080000000000000101000000000000000100000000000000000000000000000019"},
+		{Name: "Float", Input: values.Float(1.5), Expect: magicHex + "233ff8000000000000080000000000000101000000000000000100000000000000000000000000000011"},
+		{Name: "String/ASCII", Input: values.String("abc"), Expect: magicHex + "5361626308000000000000010100000000000000010000000000000000000000000000000c"},
+		{Name: "String/Unicode", Input: values.String("é"), Expect: magicHex + "6100e908000000000000010100000000000000010000000000000000000000000000000b"},
+		{Name: "Data", Input: values.Bytes{1, 2, 3}, Expect: magicHex + "4301020308000000000000010100000000000000010000000000000000000000000000000c"},
+		{Name: "Date", Input: values.Time(time.Date(2001, 1, 1, 0, 1, 0, 0, time.UTC)), Expect: magicHex + "33404e000000000000080000000000000101000000000000000100000000000000000000000000000011"},
+		{
+			Name: "Dict",
+			Input: values.Object{
+				{Key: "a", Value: values.String("a")},
+				{Key: "b", Value: values.Array{values.Int(1), values.Object(nil)}},
+			},
+			Expect: magicHex + "d20102010351615162a204051001d0080d0f1114160000000000000101000000000000000600000000000000000000000000000017",
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, Binary{}.NewGenerator())
+			row.Input.EmitTo(&e)
+			err := e.Close()
+
+			if !reflect.DeepEqual(err, row.ExpectErr) {
+				t.Errorf("wrong error:\n\texpect: %#v\n\tactual: %#v", row.ExpectErr, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+
+			if actual := hex.EncodeToString(buf.Bytes()); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %s\n\tactual: %s", row.Expect, actual)
+			}
+		})
+	}
+}
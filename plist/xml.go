@@ -0,0 +1,270 @@
+package plist
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/states"
+	"github.com/chronos-tachyon/go-emitter/xml"
+)
+
+const (
+	xmlDeclaration = `<?xml version="1.0" encoding="UTF-8"?>`
+	xmlDoctype     = `<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">`
+	xmlDateLayout  = "2006-01-02T15:04:05Z"
+)
+
+type XMLGenerator struct {
+	xml XML
+	sm  states.Machine
+}
+
+func (g *XMLGenerator) Reset() {
+	g.sm.Reset()
+}
+
+func (g *XMLGenerator) Factory() emitter.GeneratorFactory {
+	return g.xml
+}
+
+func (g *XMLGenerator) Begin() []Appender {
+	g.sm.ExpectRoot()
+
+	var b appenders.Builder
+	b.AddString(xmlDeclaration)
+	g.xml.Format.LineFeed(&b)
+	b.AddString(xmlDoctype)
+	g.xml.Format.LineFeed(&b)
+	b.AddString(`<plist version="1.0">`)
+	return b.Build()
+}
+
+func (g *XMLGenerator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+
+	var b appenders.Builder
+	g.indentOrSpace(&b)
+	b.AddString("</plist>")
+	g.xml.Format.LineFeed(&b)
+	return b.Build()
+}
+
+func (g *XMLGenerator) StartObject() []Appender {
+	return g.startContainer("dict", states.ObjectFirstKey)
+}
+
+func (g *XMLGenerator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	return g.endContainer("dict", states.ObjectFirstKey)
+}
+
+func (g *XMLGenerator) StartArray() []Appender {
+	return g.startContainer("array", states.ArrayFirstValue)
+}
+
+func (g *XMLGenerator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	return g.endContainer("array", states.ArrayFirstValue)
+}
+
+func (g *XMLGenerator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+
+	var b appenders.Builder
+	g.separator(&b)
+	g.element(&b, "key", key)
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *XMLGenerator) NullValue() []Appender {
+	g.sm.ExpectValue()
+	g.sm.Next()
+	return g.fail(fmt.Errorf("plist does not support null"))
+}
+
+func (g *XMLGenerator) BoolValue(value bool) []Appender {
+	if value {
+		return g.empty("true")
+	}
+	return g.empty("false")
+}
+
+func (g *XMLGenerator) IntValue(value int64) []Appender {
+	return g.scalar("integer", strconv.FormatInt(value, 10))
+}
+
+func (g *XMLGenerator) UintValue(value uint64) []Appender {
+	return g.scalar("integer", strconv.FormatUint(value, 10))
+}
+
+func (g *XMLGenerator) BigIntValue(value *big.Int) []Appender {
+	switch {
+	case value == nil:
+		return g.NullValue()
+	case value.IsInt64():
+		return g.IntValue(value.Int64())
+	case value.IsUint64():
+		return g.UintValue(value.Uint64())
+	default:
+		g.sm.ExpectValue()
+		g.sm.Next()
+		return g.fail(fmt.Errorf("plist integer %v does not fit in 64 bits", value))
+	}
+}
+
+func (g *XMLGenerator) NaNValue() []Appender {
+	return g.scalar("real", "nan")
+}
+
+func (g *XMLGenerator) InfValue(isNeg bool) []Appender {
+	if isNeg {
+		return g.scalar("real", "-infinity")
+	}
+	return g.scalar("real", "+infinity")
+}
+
+func (g *XMLGenerator) FloatValue(value float64) []Appender {
+	switch {
+	case math.IsNaN(value):
+		return g.NaNValue()
+	case math.IsInf(value, 0):
+		return g.InfValue(value < 0)
+	default:
+		return g.scalar("real", strconv.FormatFloat(value, 'g', -1, 64))
+	}
+}
+
+func (g *XMLGenerator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	f, _ := value.Float64()
+	return g.FloatValue(f)
+}
+
+func (g *XMLGenerator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	f, _ := value.Float64()
+	return g.FloatValue(f)
+}
+
+func (g *XMLGenerator) ComplexValue(value complex128) []Appender {
+	var list []Appender
+	list = append(list, g.StartObject()...)
+	list = append(list, g.Key("re")...)
+	list = append(list, g.FloatValue(real(value))...)
+	list = append(list, g.Key("im")...)
+	list = append(list, g.FloatValue(imag(value))...)
+	list = append(list, g.EndObject()...)
+	return list
+}
+
+func (g *XMLGenerator) StringValue(value string) []Appender {
+	return g.scalar("string", value)
+}
+
+func (g *XMLGenerator) BytesValue(value []byte) []Appender {
+	return g.scalar("data", base64.StdEncoding.EncodeToString(value))
+}
+
+func (g *XMLGenerator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *XMLGenerator) RuneValue(value rune) []Appender {
+	return g.StringValue(string(value))
+}
+
+func (g *XMLGenerator) TimeValue(value time.Time) []Appender {
+	return g.scalar("date", value.UTC().Format(xmlDateLayout))
+}
+
+func (g *XMLGenerator) startContainer(name string, next states.State) []Appender {
+	g.sm.ExpectValue()
+
+	var b appenders.Builder
+	g.separator(&b)
+	b.AddByte('<')
+	b.AddString(name)
+	g.sm.Push(next)
+	return b.Build()
+}
+
+// endContainer closes the element opened by startContainer, which is left
+// as "<name" until its first child so that an empty container can be written
+// as "<name/>".
+func (g *XMLGenerator) endContainer(name string, isEmpty states.State) []Appender {
+	wasEmpty := g.sm.State.In(isEmpty)
+	g.sm.Pop()
+
+	var b appenders.Builder
+	if wasEmpty {
+		b.AddString("/>")
+	} else {
+		g.indentOrSpace(&b)
+		b.AddString("</")
+		b.AddString(name)
+		b.AddByte('>')
+	}
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *XMLGenerator) separator(b *appenders.Builder) {
+	if g.sm.State.In(states.ObjectFirstKey, states.ArrayFirstValue) {
+		b.AddByte('>')
+	}
+	g.indentOrSpace(b)
+}
+
+func (g *XMLGenerator) element(b *appenders.Builder, name string, text string) {
+	b.AddByte('<')
+	b.AddString(name)
+	b.AddByte('>')
+	b.Add(xml.TextAppender{Value: text})
+	b.AddString("</")
+	b.AddString(name)
+	b.AddByte('>')
+}
+
+func (g *XMLGenerator) scalar(name string, text string) []Appender {
+	g.sm.ExpectValue()
+
+	var b appenders.Builder
+	g.separator(&b)
+	g.element(&b, name, text)
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *XMLGenerator) empty(name string) []Appender {
+	g.sm.ExpectValue()
+
+	var b appenders.Builder
+	g.separator(&b)
+	b.AddByte('<')
+	b.AddString(name)
+	b.AddString("/>")
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *XMLGenerator) fail(err error) []Appender {
+	return []Appender{appenders.Error{Err: err}}
+}
+
+func (g *XMLGenerator) indentOrSpace(b *appenders.Builder) {
+	g.xml.Format.IndentOrSpace(b, g.xml.IndentWithTabs, g.xml.IndentSize, g.sm.Depth())
+}
+
+var _ emitter.Generator = (*XMLGenerator)(nil)
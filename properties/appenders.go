@@ -0,0 +1,61 @@
+package properties
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/chronos-tachyon/go-emitter/appenders"
+)
+
+type Appender = appenders.Appender
+
+// EscapeAppender writes a key or value with the escapes used by
+// java.util.Properties.store.  Spaces are escaped everywhere in a key, but
+// only at the start of a value.
+type EscapeAppender struct {
+	Value string
+	IsKey bool
+}
+
+func (a EscapeAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a EscapeAppender) Append(out []byte) []byte {
+	for index, ch := range a.Value {
+		switch ch {
+		case ' ':
+			if index == 0 || a.IsKey {
+				out = append(out, '\\')
+			}
+			out = append(out, ' ')
+		case '\t':
+			out = append(out, '\\', 't')
+		case '\n':
+			out = append(out, '\\', 'n')
+		case '\r':
+			out = append(out, '\\', 'r')
+		case '\f':
+			out = append(out, '\\', 'f')
+		case '=', ':', '#', '!', '\\':
+			out = append(out, '\\', byte(ch))
+		default:
+			if ch >= 0x20 && ch <= 0x7e {
+				out = append(out, byte(ch))
+				continue
+			}
+			if r1, r2 := utf16.EncodeRune(ch); r1 != unicode.ReplacementChar {
+				out = fmt.Appendf(out, "\\u%04X\\u%04X", r1, r2)
+				continue
+			}
+			out = fmt.Appendf(out, "\\u%04X", ch)
+		}
+	}
+	return out
+}
+
+var (
+	_ fmt.Stringer = EscapeAppender{}
+	_ Appender     = EscapeAppender{}
+)
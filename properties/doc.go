@@ -0,0 +1,2 @@
+// Package properties implements Java .properties files for Emitter.
+package properties
@@ -0,0 +1,67 @@
+package properties
+
+import (
+	"fmt"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/flatten"
+)
+
+type formatter struct {
+	properties Properties
+}
+
+func (f formatter) Factory() emitter.GeneratorFactory {
+	return f.properties
+}
+
+func (f formatter) RootError() error {
+	return fmt.Errorf("properties file requires an object at the top level")
+}
+
+func (f formatter) Null() string {
+	return ""
+}
+
+func (f formatter) StartRecord() []Appender {
+	return nil
+}
+
+func (f formatter) EndRecord() []Appender {
+	return nil
+}
+
+func (f formatter) Name(path []string) string {
+	fn := f.properties.KeyFunc
+	if fn == nil {
+		fn = Dotted
+	}
+	return fn(path)
+}
+
+func (f formatter) DuplicateError(name string) error {
+	return fmt.Errorf("property %q is set more than once", name)
+}
+
+func (f formatter) Array(name string) []Appender {
+	if f.properties.Strict {
+		return fail(fmt.Errorf("property %q would be an array, which strict mode does not allow", name))
+	}
+	return nil
+}
+
+func (f formatter) Field(name string, value string) []Appender {
+	var b appenders.Builder
+	b.Add(EscapeAppender{Value: name, IsKey: true})
+	b.AddByte('=')
+	b.Add(EscapeAppender{Value: value})
+	b.AddByte('\n')
+	return b.Build()
+}
+
+func fail(err error) []Appender {
+	return []Appender{appenders.Error{Err: err}}
+}
+
+var _ flatten.Formatter = formatter{}
@@ -0,0 +1,30 @@
+package properties
+
+import (
+	"strings"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/flatten"
+)
+
+// KeyFunc computes a property key from the path leading to a value.
+type KeyFunc func(path []string) string
+
+// Properties generates Java .properties files.  KeyFunc defaults to Dotted.
+// If Strict is set, arrays are an error.
+type Properties struct {
+	KeyFunc KeyFunc
+	Strict  bool
+}
+
+func (properties Properties) NewGenerator() emitter.Generator {
+	return flatten.NewGenerator(formatter{properties: properties})
+}
+
+var _ emitter.GeneratorFactory = Properties{}
+
+// Dotted joins path with periods, so that {"database": {"host": ...}}
+// becomes database.host.
+func Dotted(path []string) string {
+	return strings.Join(path, ".")
+}
@@ -0,0 +1,93 @@
+package properties
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/dotenv"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+func TestProperties(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Input     Value
+		Factory   emitter.GeneratorFactory
+		Expect    string
+		ExpectErr error
+	}
+
+	config := values.Object{
+		{Key: "database", Value: values.Object{
+			{Key: "host", Value: values.String("db.example.com")},
+			{Key: "port", Value: values.Int(5432)},
+		}},
+		{Key: "servers", Value: values.Array{values.String("a"), values.String("b")}},
+	}
+
+	testData := [...]testCase{
+		{Name: "Config", Input: config, Factory: Properties{}, Expect: "database.host=db.example.com\ndatabase.port=5432\nservers.0=a\nservers.1=b\n"},
+		{Name: "Config/KeyFunc", Input: config, Factory: Properties{KeyFunc: dotenv.UpperSnake}, Expect: "DATABASE_HOST=db.example.com\nDATABASE_PORT=5432\nSERVERS_0=a\nSERVERS_1=b\n"},
+		{
+			Name: "Escaping",
+			Input: values.Object{
+				{Key: "key with spaces", Value: values.String(" leading and inner spaces")},
+				{Key: "a=b:c", Value: values.String("#!\\")},
+				{Key: "control", Value: values.String("tab\there\nnew\fline\r")},
+				{Key: "unicode", Value: values.String("héllo ☃ 𝄞")},
+				{Key: "null", Value: values.Null{}},
+			},
+			Factory: Properties{},
+			Expect:  "key\\ with\\ spaces=\\ leading and inner spaces\na\\=b\\:c=\\#\\!\\\\\ncontrol=tab\\there\\nnew\\fline\\r\nunicode=h\\u00E9llo \\u2603 \\uD834\\uDD1E\nnull=\n",
+		},
+		{
+			Name:      "Error/Strict",
+			Input:     config,
+			Factory:   Properties{Strict: true},
+			ExpectErr: fmt.Errorf("property %q would be an array, which strict mode does not allow", "servers"),
+		},
+		{
+			Name:      "Error/TopLevel",
+			Input:     values.String("x"),
+			Factory:   Properties{},
+			ExpectErr: fmt.Errorf("properties file requires an object at the top level"),
+		},
+		{
+			Name: "Error/Duplicate",
+			Input: values.Object{
+				{Key: "a.b", Value: values.Int(1)},
+				{Key: "a", Value: values.Object{{Key: "b", Value: values.Int(2)}}},
+			},
+			Factory:   Properties{},
+			ExpectErr: fmt.Errorf("property %q is set more than once", "a.b"),
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, row.Factory.NewGenerator())
+			row.Input.EmitTo(&e)
+			err := e.Close()
+
+			if !reflect.DeepEqual(err, row.ExpectErr) {
+				t.Errorf("wrong error:\n\texpect: %#v\n\tactual: %#v", row.ExpectErr, err)
+				return
+			}
+			if err != nil {
+				return
+			}
+
+			if actual := buf.String(); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
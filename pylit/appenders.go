@@ -0,0 +1,111 @@
+package pylit
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/chronos-tachyon/go-emitter/appenders"
+)
+
+type Appender = appenders.Appender
+
+// StringAppender writes a Python str literal, choosing quotes the way repr()
+// does: single quotes unless the value contains ' but not ".
+type StringAppender struct {
+	Value string
+}
+
+func (a StringAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a StringAppender) Append(out []byte) []byte {
+	quote := byte('\'')
+	if strings.IndexByte(a.Value, '\'') >= 0 && strings.IndexByte(a.Value, '"') < 0 {
+		quote = '"'
+	}
+
+	out = append(out, quote)
+	for _, ch := range a.Value {
+		switch {
+		case ch == rune(quote) || ch == '\\':
+			out = append(out, '\\', byte(ch))
+		case ch == '\t':
+			out = append(out, '\\', 't')
+		case ch == '\n':
+			out = append(out, '\\', 'n')
+		case ch == '\r':
+			out = append(out, '\\', 'r')
+		case ch < 0x20 || (ch >= 0x7f && ch < 0xa0):
+			out = fmt.Appendf(out, "\\x%02x", ch)
+		case ch == ' ' || unicode.IsPrint(ch):
+			out = utf8.AppendRune(out, ch)
+		case ch < 0x10000:
+			out = fmt.Appendf(out, "\\u%04x", ch)
+		default:
+			out = fmt.Appendf(out, "\\U%08x", ch)
+		}
+	}
+	return append(out, quote)
+}
+
+var (
+	_ fmt.Stringer = StringAppender{}
+	_ Appender     = StringAppender{}
+)
+
+type BytesAppender struct {
+	Value []byte
+}
+
+func (a BytesAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a BytesAppender) Append(out []byte) []byte {
+	out = append(out, 'b', '\'')
+	for _, ch := range a.Value {
+		switch {
+		case ch == '\'' || ch == '\\':
+			out = append(out, '\\', ch)
+		case ch == '\t':
+			out = append(out, '\\', 't')
+		case ch == '\n':
+			out = append(out, '\\', 'n')
+		case ch == '\r':
+			out = append(out, '\\', 'r')
+		case ch < 0x20 || ch >= 0x7f:
+			out = fmt.Appendf(out, "\\x%02x", ch)
+		default:
+			out = append(out, ch)
+		}
+	}
+	return append(out, '\'')
+}
+
+var (
+	_ fmt.Stringer = BytesAppender{}
+	_ Appender     = BytesAppender{}
+)
+
+type FloatAppender float64
+
+func (a FloatAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a FloatAppender) Append(out []byte) []byte {
+	start := len(out)
+	out = appenders.FloatText(a).Append(out)
+	if !strings.ContainsAny(string(out[start:]), ".eEn") {
+		out = append(out, '.', '0')
+	}
+	return out
+}
+
+var (
+	_ fmt.Stringer = FloatAppender(0)
+	_ Appender     = FloatAppender(0)
+)
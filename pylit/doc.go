@@ -0,0 +1,2 @@
+// Package pylit implements Python literals for Emitter.
+package pylit
@@ -0,0 +1,270 @@
+package pylit
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+type Generator struct {
+	python Python
+	sm     states.Machine
+}
+
+func (g *Generator) Reset() {
+	g.sm.Reset()
+}
+
+func (g *Generator) Factory() emitter.GeneratorFactory {
+	return g.python
+}
+
+func (g *Generator) Begin() []Appender {
+	g.sm.ExpectRoot()
+	return nil
+}
+
+func (g *Generator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+
+	var b appenders.Builder
+	g.python.Format.LineFeed(&b)
+	return b.Build()
+}
+
+func (g *Generator) StartObject() []Appender {
+	return g.startContainer('{', states.ObjectFirstKey)
+}
+
+func (g *Generator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	return g.endContainer('}', states.ObjectNextKey)
+}
+
+func (g *Generator) StartArray() []Appender {
+	return g.startContainer('[', states.ArrayFirstValue)
+}
+
+func (g *Generator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	return g.endContainer(']', states.ArrayNextValue)
+}
+
+func (g *Generator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+
+	var b appenders.Builder
+	g.keySeparator(&b)
+	b.Add(StringAppender{Value: key})
+	b.AddByte(':')
+	g.python.Format.Space(&b)
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) StartKey() []Appender {
+	var b appenders.Builder
+	g.keySeparator(&b)
+	g.sm.StartKey()
+	return b.Build()
+}
+
+func (g *Generator) EndKey() []Appender {
+	g.sm.EndKey()
+
+	var b appenders.Builder
+	b.AddByte(':')
+	g.python.Format.Space(&b)
+	return b.Build()
+}
+
+func (g *Generator) NullValue() []Appender {
+	return g.literal(`None`)
+}
+
+func (g *Generator) BoolValue(value bool) []Appender {
+	if value {
+		return g.literal(`True`)
+	}
+	return g.literal(`False`)
+}
+
+func (g *Generator) IntValue(value int64) []Appender {
+	return g.value(appenders.IntText(value))
+}
+
+func (g *Generator) UintValue(value uint64) []Appender {
+	return g.value(appenders.UintText(value))
+}
+
+func (g *Generator) BigIntValue(value *big.Int) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	return g.value(appenders.BigIntText{Pointer: value})
+}
+
+func (g *Generator) NaNValue() []Appender {
+	return g.literal(`float('nan')`)
+}
+
+func (g *Generator) InfValue(isNeg bool) []Appender {
+	if isNeg {
+		return g.literal(`float('-inf')`)
+	}
+	return g.literal(`float('inf')`)
+}
+
+func (g *Generator) FloatValue(value float64) []Appender {
+	switch {
+	case math.IsNaN(value):
+		return g.NaNValue()
+	case math.IsInf(value, 0):
+		return g.InfValue(value < 0)
+	default:
+		return g.value(FloatAppender(value))
+	}
+}
+
+func (g *Generator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if value.IsInf() {
+		return g.InfValue(value.Signbit())
+	}
+	if f, acc := value.Float64(); acc == big.Exact {
+		return g.FloatValue(f)
+	}
+	return g.literal(fmt.Sprintf("Decimal('%s')", value.Text('g', -1)))
+}
+
+func (g *Generator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	return g.literal(fmt.Sprintf("Fraction(%s, %s)", value.Num(), value.Denom()))
+}
+
+func (g *Generator) ComplexValue(value complex128) []Appender {
+	return g.literal(fmt.Sprintf("complex(%s, %s)", floatText(real(value)), floatText(imag(value))))
+}
+
+func (g *Generator) StringValue(value string) []Appender {
+	return g.value(StringAppender{Value: value})
+}
+
+func (g *Generator) BytesValue(value []byte) []Appender {
+	return g.value(BytesAppender{Value: value})
+}
+
+func (g *Generator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *Generator) RuneValue(value rune) []Appender {
+	return g.StringValue(string(value))
+}
+
+func (g *Generator) TimeValue(value time.Time) []Appender {
+	tz := "datetime.timezone.utc"
+	if _, offset := value.Zone(); offset != 0 {
+		tz = fmt.Sprintf("datetime.timezone(datetime.timedelta(seconds=%d))", offset)
+	}
+	return g.literal(fmt.Sprintf("datetime.datetime(%d, %d, %d, %d, %d, %d, %d, tzinfo=%s)",
+		value.Year(), value.Month(), value.Day(),
+		value.Hour(), value.Minute(), value.Second(), value.Nanosecond()/1000, tz))
+}
+
+func floatText(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return `float('nan')`
+	case math.IsInf(value, 1):
+		return `float('inf')`
+	case math.IsInf(value, -1):
+		return `float('-inf')`
+	default:
+		return FloatAppender(value).String()
+	}
+}
+
+func (g *Generator) startContainer(open byte, next states.State) []Appender {
+	g.sm.ExpectValue()
+	if g.sm.InKey() {
+		g.sm.Push(next)
+		return []Appender{appenders.Error{Err: fmt.Errorf("Python dict keys must be hashable, so they cannot be lists or dicts")}}
+	}
+
+	var b appenders.Builder
+	g.separator(&b)
+	g.sm.Push(next)
+	b.AddByte(open)
+	return b.Build()
+}
+
+func (g *Generator) endContainer(close byte, nonEmpty states.State) []Appender {
+	needIndent := g.sm.State.In(nonEmpty)
+	g.sm.Pop()
+
+	var b appenders.Builder
+	if needIndent {
+		g.indent(&b)
+	}
+	b.AddByte(close)
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) keySeparator(b *appenders.Builder) {
+	if g.sm.State.In(states.ObjectFirstKey) {
+		g.indent(b)
+	}
+	if g.sm.State.In(states.ObjectNextKey) {
+		b.AddByte(',')
+		g.indentOrSpace(b)
+	}
+}
+
+func (g *Generator) separator(b *appenders.Builder) {
+	if g.sm.State.In(states.ArrayFirstValue) {
+		g.indent(b)
+	}
+	if g.sm.State.In(states.ArrayNextValue) {
+		b.AddByte(',')
+		g.indentOrSpace(b)
+	}
+}
+
+func (g *Generator) value(a Appender) []Appender {
+	g.sm.ExpectValue()
+
+	var b appenders.Builder
+	g.separator(&b)
+	b.Add(a)
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) literal(str string) []Appender {
+	return g.value(appenders.LiteralString(str))
+}
+
+func (g *Generator) indent(b *appenders.Builder) {
+	g.python.Format.Indent(b, g.python.IndentWithTabs, g.python.IndentSize, g.sm.Depth())
+}
+
+func (g *Generator) indentOrSpace(b *appenders.Builder) {
+	g.python.Format.IndentOrSpace(b, g.python.IndentWithTabs, g.python.IndentSize, g.sm.Depth())
+}
+
+var (
+	_ emitter.Generator    = (*Generator)(nil)
+	_ emitter.KeyGenerator = (*Generator)(nil)
+)
@@ -0,0 +1,20 @@
+package pylit
+
+import (
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/json"
+)
+
+type Python struct {
+	Format         json.Format
+	IndentSize     uint
+	IndentWithTabs bool
+}
+
+func (python Python) NewGenerator() emitter.Generator {
+	g := &Generator{python: python}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = Python{}
@@ -0,0 +1,91 @@
+package pylit
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/json"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+func TestPython(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Input     Value
+		Factory   emitter.GeneratorFactory
+		Expect    string
+		ExpectErr error
+	}
+
+	kFancyValue := values.Object{
+		{Key: "none", Value: values.Null{}},
+		{Key: "flags", Value: values.Array{values.Bool(true), values.Bool(false)}},
+		{Key: "it's", Value: values.String("tab\there \u00e9\u200b\x7f")},
+		{Key: "raw", Value: values.Bytes("a'\\\x00\xff")},
+		{Key: "nums", Value: values.Array{
+			values.Float(1),
+			values.Float(math.NaN()),
+			values.Float(math.Inf(-1)),
+			values.BigRatValue{Pointer: big.NewRat(1, 3)},
+			values.Complex(complex(1.5, -2)),
+		}},
+	}
+
+	testData := [...]testCase{
+		{
+			Name:    "Compact",
+			Input:   kFancyValue,
+			Factory: Python{},
+			Expect:  "{'none':None,'flags':[True,False],\"it's\":'tab\\there \u00e9\\u200b\\x7f','raw':b'a\\'\\\\\\x00\\xff','nums':[1.0,float('nan'),float('-inf'),Fraction(1, 3),complex(1.5, -2.0)]}",
+		},
+		{
+			Name:    "MultiLine",
+			Input:   values.Array{values.Object{{Key: "a", Value: values.Int(1)}}, values.Array(nil)},
+			Factory: Python{Format: json.MultiLine, IndentSize: 4},
+			Expect:  "[\n    {\n        'a': 1\n    },\n    []\n]\n",
+		},
+		{
+			Name: "Map",
+			Input: values.Map{
+				{Key: values.Int(1), Value: values.String("one")},
+				{Key: values.Bool(true), Value: values.Time(time.Date(2023, 9, 1, 12, 30, 0, 500000000, time.UTC))},
+			},
+			Factory: Python{Format: json.OneLine},
+			Expect:  "{1: 'one', True: datetime.datetime(2023, 9, 1, 12, 30, 0, 500000, tzinfo=datetime.timezone.utc)}\n",
+		},
+		{
+			Name:      "Map/ListKey",
+			Input:     values.Map{{Key: values.Array{values.Int(1)}, Value: values.Int(2)}},
+			Factory:   Python{},
+			ExpectErr: fmt.Errorf("Python dict keys must be hashable, so they cannot be lists or dicts"),
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, row.Factory.NewGenerator())
+			row.Input.EmitTo(&e)
+			err := e.Close()
+
+			if !reflect.DeepEqual(err, row.ExpectErr) {
+				t.Errorf("wrong error:\n\texpect: %#v\n\tactual: %#v", row.ExpectErr, err)
+				return
+			}
+
+			if actual := buf.String(); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package sexpr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chronos-tachyon/go-emitter/appenders"
+)
+
+type Appender = appenders.Appender
+
+// IsSymbol reports whether str can be written as a bare symbol that reads
+// back with the same name.  Upper case letters are excluded, since many Lisp
+// readers fold them.
+func IsSymbol(str string) bool {
+	if str == "" || str == "t" || str == "nil" || strings.Trim(str, ".") == "" || looksNumeric(str) {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		ch := str[i]
+		switch {
+		case ch >= 'a' && ch <= 'z':
+		case ch >= '0' && ch <= '9':
+		case strings.IndexByte("-_*+!?<>=/%&$^~.", ch) >= 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// looksNumeric reports whether a Lisp reader would start parsing str as a
+// number: an optional sign, an optional '.', and then a digit.
+func looksNumeric(str string) bool {
+	i := 0
+	if i < len(str) && (str[i] == '+' || str[i] == '-') {
+		i++
+	}
+	if i < len(str) && str[i] == '.' {
+		i++
+	}
+	return i < len(str) && str[i] >= '0' && str[i] <= '9'
+}
+
+type StringAppender struct {
+	Value string
+}
+
+func (a StringAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a StringAppender) Append(out []byte) []byte {
+	out = append(out, '"')
+	for i := 0; i < len(a.Value); i++ {
+		ch := a.Value[i]
+		if ch == '"' || ch == '\\' {
+			out = append(out, '\\')
+		}
+		out = append(out, ch)
+	}
+	return append(out, '"')
+}
+
+var (
+	_ fmt.Stringer = StringAppender{}
+	_ Appender     = StringAppender{}
+)
+
+// FloatAppender writes a finite float so that it reads back as a float, not
+// an integer.
+type FloatAppender float64
+
+func (a FloatAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a FloatAppender) Append(out []byte) []byte {
+	start := len(out)
+	out = strconv.AppendFloat(out, float64(a), 'g', -1, 64)
+	if !strings.ContainsAny(string(out[start:]), ".e") {
+		out = append(out, '.', '0')
+	}
+	return out
+}
+
+var (
+	_ fmt.Stringer = FloatAppender(0)
+	_ Appender     = FloatAppender(0)
+)
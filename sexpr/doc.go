@@ -0,0 +1,2 @@
+// Package sexpr implements Lisp S-expressions for Emitter.
+package sexpr
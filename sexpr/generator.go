@@ -0,0 +1,291 @@
+package sexpr
+
+import (
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/json"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+type Generator struct {
+	sexpr SExpr
+	sm    states.Machine
+}
+
+func (g *Generator) Reset() {
+	g.sm.Reset()
+}
+
+func (g *Generator) Factory() emitter.GeneratorFactory {
+	return g.sexpr
+}
+
+func (g *Generator) Begin() []Appender {
+	g.sm.ExpectRoot()
+	return nil
+}
+
+func (g *Generator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+
+	var b appenders.Builder
+	g.sexpr.Format.LineFeed(&b)
+	return b.Build()
+}
+
+func (g *Generator) StartObject() []Appender {
+	return g.startContainer(states.ObjectFirstKey)
+}
+
+func (g *Generator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	return g.endContainer(states.ObjectNextKey)
+}
+
+func (g *Generator) StartArray() []Appender {
+	return g.startContainer(states.ArrayFirstValue)
+}
+
+func (g *Generator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	return g.endContainer(states.ArrayNextValue)
+}
+
+func (g *Generator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+
+	var b appenders.Builder
+	g.startEntry(&b)
+	switch {
+	case g.sexpr.SymbolKeys && IsSymbol(key) && g.sexpr.Objects == PList:
+		b.AddByte(':')
+		b.AddString(key)
+	case g.sexpr.SymbolKeys && IsSymbol(key):
+		b.AddString(key)
+	default:
+		b.Add(StringAppender{Value: key})
+	}
+	g.endKey(&b)
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) StartKey() []Appender {
+	var b appenders.Builder
+	g.startEntry(&b)
+	g.sm.StartKey()
+	return b.Build()
+}
+
+func (g *Generator) EndKey() []Appender {
+	g.sm.EndKey()
+
+	var b appenders.Builder
+	g.endKey(&b)
+	return b.Build()
+}
+
+func (g *Generator) NullValue() []Appender {
+	return g.atom(g.sexpr.Nil, `nil`)
+}
+
+func (g *Generator) BoolValue(value bool) []Appender {
+	if value {
+		return g.atom(g.sexpr.True, `t`)
+	}
+	return g.atom(g.sexpr.False, `nil`)
+}
+
+func (g *Generator) IntValue(value int64) []Appender {
+	return g.value(appenders.IntText(value))
+}
+
+func (g *Generator) UintValue(value uint64) []Appender {
+	return g.value(appenders.UintText(value))
+}
+
+func (g *Generator) BigIntValue(value *big.Int) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	return g.value(appenders.BigIntText{Pointer: value})
+}
+
+func (g *Generator) NaNValue() []Appender {
+	return g.literal(`"NaN"`)
+}
+
+func (g *Generator) InfValue(isNeg bool) []Appender {
+	if isNeg {
+		return g.literal(`"-Inf"`)
+	}
+	return g.literal(`"+Inf"`)
+}
+
+func (g *Generator) FloatValue(value float64) []Appender {
+	switch {
+	case math.IsNaN(value):
+		return g.NaNValue()
+	case math.IsInf(value, 0):
+		return g.InfValue(value < 0)
+	default:
+		return g.value(FloatAppender(value))
+	}
+}
+
+func (g *Generator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	f, _ := value.Float64()
+	return g.FloatValue(f)
+}
+
+func (g *Generator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if value.IsInt() {
+		return g.BigIntValue(value.Num())
+	}
+	return g.literal(value.Num().String() + "/" + value.Denom().String())
+}
+
+func (g *Generator) ComplexValue(value complex128) []Appender {
+	re, im := real(value), imag(value)
+	if !math.IsNaN(re) && !math.IsInf(re, 0) && !math.IsNaN(im) && !math.IsInf(im, 0) {
+		return g.literal("#C(" + FloatAppender(re).String() + " " + FloatAppender(im).String() + ")")
+	}
+
+	var list []Appender
+	list = append(list, g.StartObject()...)
+	list = append(list, g.Key("re")...)
+	list = append(list, g.FloatValue(re)...)
+	list = append(list, g.Key("im")...)
+	list = append(list, g.FloatValue(im)...)
+	list = append(list, g.EndObject()...)
+	return list
+}
+
+func (g *Generator) StringValue(value string) []Appender {
+	return g.value(StringAppender{Value: value})
+}
+
+func (g *Generator) BytesValue(value []byte) []Appender {
+	return g.value(json.BytesAppender{Value: value})
+}
+
+func (g *Generator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *Generator) RuneValue(value rune) []Appender {
+	return g.StringValue(string(value))
+}
+
+func (g *Generator) TimeValue(value time.Time) []Appender {
+	return g.StringValue(value.Format(time.RFC3339Nano))
+}
+
+func (g *Generator) startContainer(next states.State) []Appender {
+	g.sm.ExpectValue()
+
+	var b appenders.Builder
+	g.separator(&b)
+	g.sm.Push(next)
+	b.AddByte('(')
+	return b.Build()
+}
+
+func (g *Generator) endContainer(nonEmpty states.State) []Appender {
+	needIndent := g.sm.State.In(nonEmpty)
+	g.sm.Pop()
+
+	var b appenders.Builder
+	if needIndent {
+		g.indent(&b)
+	}
+	b.AddByte(')')
+	g.next(&b)
+	return b.Build()
+}
+
+// startEntry writes everything that precedes an object key: the separator,
+// plus the opening of the dotted pair for association lists.
+func (g *Generator) startEntry(b *appenders.Builder) {
+	g.separator(b)
+	if g.sexpr.Objects == AList {
+		b.AddByte('(')
+	}
+}
+
+func (g *Generator) endKey(b *appenders.Builder) {
+	if g.sexpr.Objects == AList {
+		b.AddString(" . ")
+		return
+	}
+	b.AddByte(' ')
+}
+
+// separator writes the whitespace between list elements, which (unlike JSON)
+// cannot be omitted even in compact output.
+func (g *Generator) separator(b *appenders.Builder) {
+	if g.sm.State.In(states.ObjectFirstKey, states.ArrayFirstValue) {
+		g.indent(b)
+	}
+	if g.sm.State.In(states.ObjectNextKey, states.ArrayNextValue) {
+		if g.sexpr.Format == json.Compact {
+			b.AddByte(' ')
+		} else {
+			g.indentOrSpace(b)
+		}
+	}
+}
+
+// next advances past a completed value, closing the dotted pair if the value
+// belonged to an association list entry.
+func (g *Generator) next(b *appenders.Builder) {
+	if g.sexpr.Objects == AList && g.sm.State.In(states.ObjectFirstValue, states.ObjectNextValue) {
+		b.AddByte(')')
+	}
+	g.sm.Next()
+}
+
+func (g *Generator) value(a Appender) []Appender {
+	g.sm.ExpectValue()
+
+	var b appenders.Builder
+	g.separator(&b)
+	b.Add(a)
+	g.next(&b)
+	return b.Build()
+}
+
+func (g *Generator) literal(str string) []Appender {
+	return g.value(appenders.LiteralString(str))
+}
+
+func (g *Generator) atom(str string, defaultStr string) []Appender {
+	if str == "" {
+		str = defaultStr
+	}
+	return g.literal(str)
+}
+
+func (g *Generator) indent(b *appenders.Builder) {
+	g.sexpr.Format.Indent(b, g.sexpr.IndentWithTabs, g.sexpr.IndentSize, g.sm.Depth())
+}
+
+func (g *Generator) indentOrSpace(b *appenders.Builder) {
+	g.sexpr.Format.IndentOrSpace(b, g.sexpr.IndentWithTabs, g.sexpr.IndentSize, g.sm.Depth())
+}
+
+var (
+	_ emitter.Generator    = (*Generator)(nil)
+	_ emitter.KeyGenerator = (*Generator)(nil)
+)
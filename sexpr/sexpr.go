@@ -0,0 +1,96 @@
+package sexpr
+
+import (
+	"encoding"
+	"fmt"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/json"
+)
+
+// SExpr generates S-expressions.  Objects are written as association lists
+// or property lists, as selected by Objects.  If SymbolKeys is set, string
+// keys that are valid symbol names are written as symbols (or keywords, in
+// property lists).  Nil, True, and False override the default atoms "nil",
+// "t", and "nil".
+type SExpr struct {
+	Format         json.Format
+	IndentSize     uint
+	IndentWithTabs bool
+	Objects        Objects
+	SymbolKeys     bool
+	Nil            string
+	True           string
+	False          string
+}
+
+func (sexpr SExpr) NewGenerator() emitter.Generator {
+	g := &Generator{sexpr: sexpr}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = SExpr{}
+
+type Objects byte
+
+const (
+	AList Objects = iota
+	PList
+)
+
+const objectsSize = 2
+
+var objectsGoNames = [objectsSize]string{
+	"sexpr.AList",
+	"sexpr.PList",
+}
+
+var objectsNames = [objectsSize]string{
+	"alist",
+	"plist",
+}
+
+func (o Objects) IsValid() bool {
+	return o < objectsSize
+}
+
+func (o Objects) GoString() string {
+	if o.IsValid() {
+		return objectsGoNames[o]
+	}
+	return fmt.Sprintf("sexpr.Objects(%d)", uint(o))
+}
+
+func (o Objects) String() string {
+	if o.IsValid() {
+		return objectsNames[o]
+	}
+	return fmt.Sprintf("%%!ERR[invalid sexpr.Objects %d]", uint(o))
+}
+
+func (o Objects) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+func (o *Objects) Parse(input string) error {
+	for index, name := range objectsNames {
+		if input == name {
+			*o = Objects(index)
+			return nil
+		}
+	}
+	*o = ^Objects(0)
+	return fmt.Errorf("failed to parse %q as sexpr.Objects", input)
+}
+
+func (o *Objects) UnmarshalText(input []byte) error {
+	return o.Parse(string(input))
+}
+
+var (
+	_ fmt.GoStringer           = Objects(0)
+	_ fmt.Stringer             = Objects(0)
+	_ encoding.TextMarshaler   = Objects(0)
+	_ encoding.TextUnmarshaler = (*Objects)(nil)
+)
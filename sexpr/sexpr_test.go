@@ -0,0 +1,101 @@
+package sexpr
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/json"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+func TestSExpr(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Input     Value
+		Factory   emitter.GeneratorFactory
+		Expect    string
+		ExpectErr error
+	}
+
+	kFancyValue := values.Object{
+		{Key: "id", Value: values.String("123E4567-e89b-12d3-a456-426614174000")},
+		{Key: "user name", Value: values.Null{}},
+		{Key: "flags", Value: values.Array{values.Bool(true), values.Bool(false), values.Rune('x'), values.Rune('\n')}},
+		{Key: "at", Value: values.Time(time.Date(2023, 9, 1, 12, 30, 0, 500000000, time.UTC))},
+		{Key: "nums", Value: values.Array{
+			values.Float(1),
+			values.Float(math.NaN()),
+			values.Float(math.Inf(-1)),
+			values.BigRatValue{Pointer: big.NewRat(1, 3)},
+			values.Uint(math.MaxUint64),
+			values.Complex(complex(1.5, -2)),
+		}},
+	}
+
+	testData := [...]testCase{
+		{
+			Name:    "Compact",
+			Input:   kFancyValue,
+			Factory: SExpr{},
+			Expect:  "((\"id\" . \"123E4567-e89b-12d3-a456-426614174000\") (\"user name\" . nil) (\"flags\" . (t nil \"x\" \"\n\")) (\"at\" . \"2023-09-01T12:30:00.5Z\") (\"nums\" . (1.0 \"NaN\" \"-Inf\" 1/3 18446744073709551615 #C(1.5 -2.0))))",
+		},
+		{
+			Name:    "PList",
+			Input:   kFancyValue,
+			Factory: SExpr{Format: json.OneLine, Objects: PList, SymbolKeys: true},
+			Expect:  "(:id \"123E4567-e89b-12d3-a456-426614174000\" \"user name\" nil :flags (t nil \"x\" \"\n\") :at \"2023-09-01T12:30:00.5Z\" :nums (1.0 \"NaN\" \"-Inf\" 1/3 18446744073709551615 #C(1.5 -2.0)))\n",
+		},
+		{
+			Name:    "MultiLine",
+			Input:   values.Array{values.Object{{Key: "a", Value: values.Int(1)}, {Key: "b", Value: values.Array{values.Int(2)}}}, values.Array(nil)},
+			Factory: SExpr{Format: json.MultiLine, IndentSize: 2},
+			Expect:  "(\n  (\n    (\"a\" . 1)\n    (\"b\" . (\n      2\n    ))\n  )\n  ()\n)\n",
+		},
+		{
+			Name: "Map",
+			Input: values.Map{
+				{Key: values.Int(1), Value: values.String("one")},
+				{Key: values.Array{values.Bool(true)}, Value: values.Bool(false)},
+			},
+			Factory: SExpr{Format: json.OneLine, True: "#t", False: "#f"},
+			Expect:  "((1 . \"one\") ((#t) . #f))\n",
+		},
+		{
+			Name: "SymbolKeys",
+			Input: values.Object{
+				{Key: "-.5", Value: values.Int(1)},
+				{Key: "+.5e3", Value: values.Int(2)},
+				{Key: "-x1", Value: values.Int(3)},
+			},
+			Factory: SExpr{Format: json.OneLine, SymbolKeys: true},
+			Expect:  "((\"-.5\" . 1) (\"+.5e3\" . 2) (-x1 . 3))\n",
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, row.Factory.NewGenerator())
+			row.Input.EmitTo(&e)
+			err := e.Close()
+
+			if !reflect.DeepEqual(err, row.ExpectErr) {
+				t.Errorf("wrong error:\n\texpect: %#v\n\tactual: %#v", row.ExpectErr, err)
+				return
+			}
+
+			if actual := buf.String(); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %q\n\tactual: %q", row.Expect, actual)
+			}
+		})
+	}
+}
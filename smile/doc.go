@@ -0,0 +1,2 @@
+// Package smile implements the Smile binary JSON format for Emitter.
+package smile
@@ -0,0 +1,186 @@
+package smile
+
+import (
+	"math"
+	"math/big"
+	"unicode/utf8"
+
+	"github.com/chronos-tachyon/go-emitter/appenders"
+)
+
+const (
+	headerFlagSharedNames  = 0x01
+	headerFlagSharedValues = 0x02
+	headerFlagRawBinary    = 0x04
+)
+
+const (
+	tokenEmptyString     = 0x20
+	tokenNull            = 0x21
+	tokenFalse           = 0x22
+	tokenTrue            = 0x23
+	tokenInt32           = 0x24
+	tokenInt64           = 0x25
+	tokenBigInteger      = 0x26
+	tokenFloat32         = 0x28
+	tokenFloat64         = 0x29
+	tokenBigDecimal      = 0x2a
+	tokenTinyASCII       = 0x40
+	tokenSmallASCII      = 0x60
+	tokenTinyUnicode     = 0x80
+	tokenSmallUnicode    = 0xa0
+	tokenSmallInt        = 0xc0
+	tokenLongASCII       = 0xe0
+	tokenLongUnicode     = 0xe4
+	tokenBinary7Bit      = 0xe8
+	tokenLongSharedValue = 0xec
+	tokenStartArray      = 0xf8
+	tokenEndArray        = 0xf9
+	tokenStartObject     = 0xfa
+	tokenEndObject       = 0xfb
+	tokenEndString       = 0xfc
+	tokenRawBinary       = 0xfd
+	tokenKeyLongShared   = 0x30
+	tokenKeyLongUnicode  = 0x34
+	tokenKeyShortShared  = 0x40
+	tokenKeyShortASCII   = 0x80
+	tokenKeyShortUnicode = 0xc0
+)
+
+const (
+	maxShortSharedValue = 30
+	maxShortSharedKey   = 63
+	maxSharedEntries    = 1024
+	maxShortKeyASCII    = 64
+	maxShortKeyUnicode  = 57
+	maxTinyASCII        = 32
+	maxSmallASCII       = 64
+	maxTinyUnicode      = 33
+	maxSmallUnicode     = 65
+)
+
+// table is a back-reference table for either keys or string values.
+type table struct {
+	index map[string]int
+	count int
+}
+
+func (t *table) reset() {
+	clear(t.index)
+	t.count = 0
+}
+
+func (t *table) find(str string) (int, bool) {
+	index, found := t.index[str]
+	return index, found
+}
+
+func (t *table) add(str string) {
+	if t.count >= maxSharedEntries {
+		t.reset()
+	}
+	if t.index == nil {
+		t.index = make(map[string]int)
+	}
+	t.index[str] = t.count
+	t.count++
+}
+
+func isASCII(str string) bool {
+	for i := 0; i < len(str); i++ {
+		if str[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+func zigzag32(value int32) uint64 {
+	return uint64(uint32((value << 1) ^ (value >> 31)))
+}
+
+func zigzag64(value int64) uint64 {
+	return uint64((value << 1) ^ (value >> 63))
+}
+
+// appendVInt writes an unsigned variable-length integer: 7 bits per byte,
+// most significant first, ending with a byte that has its high bit set and
+// carries the last 6 bits.
+func appendVInt(out []byte, value uint64) []byte {
+	var tmp [11]byte
+	i := len(tmp) - 1
+	tmp[i] = 0x80 | byte(value&0x3f)
+	value >>= 6
+	for value != 0 {
+		i--
+		tmp[i] = byte(value & 0x7f)
+		value >>= 7
+	}
+	return append(out, tmp[i:]...)
+}
+
+// append7Bits writes the low n bits of value as 7-bit groups, most
+// significant first.  n must be a multiple of 7.
+func append7Bits(out []byte, value uint64, n int) []byte {
+	for shift := n - 7; shift >= 0; shift -= 7 {
+		out = append(out, byte(value>>uint(shift))&0x7f)
+	}
+	return out
+}
+
+// append7BitData writes data in the 7-bit encoding: each 7 bytes become 8,
+// and a final partial chunk of n bytes becomes n+1, the last of which holds
+// the remaining n bits right-aligned.
+func append7BitData(out []byte, data []byte) []byte {
+	out = appendVInt(out, uint64(len(data)))
+	for len(data) >= 7 {
+		var chunk uint64
+		for _, b := range data[:7] {
+			chunk = chunk<<8 | uint64(b)
+		}
+		out = append7Bits(out, chunk, 56)
+		data = data[7:]
+	}
+
+	n := len(data)
+	if n <= 0 {
+		return out
+	}
+	var chunk uint64
+	for _, b := range data {
+		chunk = chunk<<8 | uint64(b)
+	}
+	out = append7Bits(out, chunk>>uint(n), 7*n)
+	return append(out, byte(chunk)&byte(1<<uint(n)-1))
+}
+
+func appendFloat(out []byte, value float64) []byte {
+	if f32 := float32(value); float64(f32) == value || math.IsNaN(value) {
+		out = append(out, tokenFloat32)
+		return append7Bits(out, uint64(math.Float32bits(f32)), 35)
+	}
+	out = append(out, tokenFloat64)
+	return append7Bits(out, math.Float64bits(value), 70)
+}
+
+func appendInt(out []byte, value int64) []byte {
+	switch {
+	case value >= -16 && value <= 15:
+		return append(out, tokenSmallInt|byte(zigzag64(value)))
+	case value >= math.MinInt32 && value <= math.MaxInt32:
+		return appendVInt(append(out, tokenInt32), zigzag32(int32(value)))
+	default:
+		return appendVInt(append(out, tokenInt64), zigzag64(value))
+	}
+}
+
+func appendBigInteger(out []byte, value *big.Int) []byte {
+	out = append(out, tokenBigInteger)
+	return append7BitData(out, appenders.TwosComplement(value))
+}
+
+func appendBigDecimal(out []byte, unscaled *big.Int, scale int32) []byte {
+	out = append(out, tokenBigDecimal)
+	out = appendVInt(out, zigzag32(scale))
+	return append7BitData(out, appenders.TwosComplement(unscaled))
+}
@@ -0,0 +1,296 @@
+package smile
+
+import (
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+type Appender = appenders.Appender
+
+type Generator struct {
+	smile  Smile
+	sm     states.Machine
+	names  table
+	values table
+}
+
+func (g *Generator) Reset() {
+	g.sm.Reset()
+	g.names.reset()
+	g.values.reset()
+}
+
+func (g *Generator) Factory() emitter.GeneratorFactory {
+	return g.smile
+}
+
+func (g *Generator) Begin() []Appender {
+	g.sm.ExpectRoot()
+	if g.smile.OmitHeader {
+		return nil
+	}
+
+	var flags byte
+	if !g.smile.DisableSharedNames {
+		flags |= headerFlagSharedNames
+	}
+	if g.smile.SharedValues {
+		flags |= headerFlagSharedValues
+	}
+	if g.smile.RawBinary {
+		flags |= headerFlagRawBinary
+	}
+	return []Appender{appenders.LiteralBytes{':', ')', '\n', flags}}
+}
+
+func (g *Generator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+	return nil
+}
+
+func (g *Generator) NextDocument() []Appender {
+	g.sm.NextDocument()
+	return nil
+}
+
+func (g *Generator) StartObject() []Appender {
+	g.sm.ExpectValue()
+	g.sm.Push(states.ObjectFirstKey)
+	return []Appender{appenders.LiteralBytes{tokenStartObject}}
+}
+
+func (g *Generator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	g.sm.Pop()
+	g.sm.Next()
+	return []Appender{appenders.LiteralBytes{tokenEndObject}}
+}
+
+func (g *Generator) StartArray() []Appender {
+	g.sm.ExpectValue()
+	g.sm.Push(states.ArrayFirstValue)
+	return []Appender{appenders.LiteralBytes{tokenStartArray}}
+}
+
+func (g *Generator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	g.sm.Pop()
+	g.sm.Next()
+	return []Appender{appenders.LiteralBytes{tokenEndArray}}
+}
+
+func (g *Generator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+	g.sm.Next()
+	return []Appender{appenders.LiteralBytes(g.appendKey(nil, key))}
+}
+
+func (g *Generator) NullValue() []Appender {
+	return g.value([]byte{tokenNull})
+}
+
+func (g *Generator) BoolValue(value bool) []Appender {
+	if value {
+		return g.value([]byte{tokenTrue})
+	}
+	return g.value([]byte{tokenFalse})
+}
+
+func (g *Generator) IntValue(value int64) []Appender {
+	return g.value(appendInt(nil, value))
+}
+
+func (g *Generator) UintValue(value uint64) []Appender {
+	if value > math.MaxInt64 {
+		return g.BigIntValue(new(big.Int).SetUint64(value))
+	}
+	return g.IntValue(int64(value))
+}
+
+func (g *Generator) BigIntValue(value *big.Int) []Appender {
+	switch {
+	case value == nil:
+		return g.NullValue()
+	case value.IsInt64():
+		return g.IntValue(value.Int64())
+	default:
+		return g.value(appendBigInteger(nil, value))
+	}
+}
+
+func (g *Generator) NaNValue() []Appender {
+	return g.FloatValue(math.NaN())
+}
+
+func (g *Generator) InfValue(isNeg bool) []Appender {
+	if isNeg {
+		return g.FloatValue(math.Inf(-1))
+	}
+	return g.FloatValue(math.Inf(1))
+}
+
+func (g *Generator) FloatValue(value float64) []Appender {
+	return g.value(appendFloat(nil, value))
+}
+
+func (g *Generator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+
+	f, acc := value.Float64()
+	if acc == big.Exact || value.IsInf() {
+		return g.FloatValue(f)
+	}
+	r, _ := value.Rat(nil)
+	return g.BigRatValue(r)
+}
+
+func (g *Generator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if value.IsInt() {
+		return g.BigIntValue(value.Num())
+	}
+
+	f, exact := value.Float64()
+	if exact {
+		return g.FloatValue(f)
+	}
+	if digits, ok := appenders.TerminatingDigits(value); ok && digits <= math.MaxInt32 {
+		scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+		unscaled := new(big.Int).Mul(value.Num(), scale)
+		unscaled.Quo(unscaled, value.Denom())
+		return g.value(appendBigDecimal(nil, unscaled, int32(digits)))
+	}
+	return g.FloatValue(f)
+}
+
+func (g *Generator) ComplexValue(value complex128) []Appender {
+	out := []byte{tokenStartArray}
+	out = appendFloat(out, real(value))
+	out = appendFloat(out, imag(value))
+	out = append(out, tokenEndArray)
+	return g.value(out)
+}
+
+func (g *Generator) StringValue(value string) []Appender {
+	return g.value(g.appendString(nil, value))
+}
+
+func (g *Generator) BytesValue(value []byte) []Appender {
+	if g.smile.RawBinary {
+		out := appendVInt([]byte{tokenRawBinary}, uint64(len(value)))
+		return g.value(append(out, value...))
+	}
+	return g.value(append7BitData([]byte{tokenBinary7Bit}, value))
+}
+
+func (g *Generator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *Generator) RuneValue(value rune) []Appender {
+	return g.StringValue(string(value))
+}
+
+func (g *Generator) TimeValue(value time.Time) []Appender {
+	return g.StringValue(value.Format(time.RFC3339Nano))
+}
+
+func (g *Generator) appendKey(out []byte, key string) []byte {
+	n := len(key)
+	if n <= 0 {
+		return append(out, tokenEmptyString)
+	}
+
+	shared := !g.smile.DisableSharedNames
+	if shared {
+		if index, found := g.names.find(key); found && usableIndex(index, maxShortSharedKey) {
+			if index <= maxShortSharedKey {
+				return append(out, tokenKeyShortShared|byte(index))
+			}
+			return append(out, tokenKeyLongShared|byte(index>>8), byte(index))
+		}
+		g.names.add(key)
+	}
+
+	ascii := isASCII(key)
+	switch {
+	case ascii && n <= maxShortKeyASCII:
+		out = append(out, tokenKeyShortASCII|byte(n-1))
+		return append(out, key...)
+	case !ascii && n <= maxShortKeyUnicode:
+		out = append(out, tokenKeyShortUnicode+byte(n-2))
+		return append(out, key...)
+	default:
+		out = append(out, tokenKeyLongUnicode)
+		out = append(out, key...)
+		return append(out, tokenEndString)
+	}
+}
+
+func (g *Generator) appendString(out []byte, str string) []byte {
+	n := len(str)
+	if n <= 0 {
+		return append(out, tokenEmptyString)
+	}
+
+	ascii := isASCII(str)
+	short := (ascii && n <= maxSmallASCII) || (!ascii && n <= maxSmallUnicode)
+	if g.smile.SharedValues && short {
+		if index, found := g.values.find(str); found && usableIndex(index, maxShortSharedValue) {
+			if index <= maxShortSharedValue {
+				return append(out, byte(index+1))
+			}
+			return append(out, tokenLongSharedValue|byte(index>>8), byte(index))
+		}
+		g.values.add(str)
+	}
+
+	switch {
+	case ascii && n <= maxTinyASCII:
+		out = append(out, tokenTinyASCII|byte(n-1))
+	case ascii && n <= maxSmallASCII:
+		out = append(out, tokenSmallASCII|byte(n-33))
+	case !ascii && n <= maxTinyUnicode:
+		out = append(out, tokenTinyUnicode|byte(n-2))
+	case !ascii && n <= maxSmallUnicode:
+		out = append(out, tokenSmallUnicode|byte(n-34))
+	case ascii:
+		out = append(out, tokenLongASCII)
+		out = append(out, str...)
+		return append(out, tokenEndString)
+	default:
+		out = append(out, tokenLongUnicode)
+		out = append(out, str...)
+		return append(out, tokenEndString)
+	}
+	return append(out, str...)
+}
+
+// usableIndex reports whether a back-reference to index can be written.
+// Two-byte references whose second byte would be 0xfe or 0xff are avoided;
+// the string is written out again instead, which also gives it a new index.
+func usableIndex(index int, maxShort int) bool {
+	low := index & 0xff
+	return index <= maxShort || (low != 0xfe && low != 0xff)
+}
+
+func (g *Generator) value(data []byte) []Appender {
+	g.sm.ExpectValue()
+	g.sm.Next()
+	return []Appender{appenders.LiteralBytes(data)}
+}
+
+var (
+	_ emitter.Generator       = (*Generator)(nil)
+	_ emitter.StreamGenerator = (*Generator)(nil)
+)
@@ -0,0 +1,23 @@
+package smile
+
+import (
+	"github.com/chronos-tachyon/go-emitter"
+)
+
+// Smile generates the Smile format.  The header is written unless OmitHeader
+// is set, in which case the reader must already agree on the three feature
+// flags.
+type Smile struct {
+	OmitHeader         bool
+	DisableSharedNames bool
+	SharedValues       bool
+	RawBinary          bool
+}
+
+func (smile Smile) NewGenerator() emitter.Generator {
+	g := &Generator{smile: smile}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = Smile{}
@@ -0,0 +1,113 @@
+package smile
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+func TestSmile(t *testing.T) {
+	type testCase struct {
+		Name    string
+		Input   Value
+		Factory emitter.GeneratorFactory
+		Expect  string
+	}
+
+	plain := Smile{}
+	bare := Smile{OmitHeader: true}
+
+	huge := new(big.Int).Lsh(big.NewInt(1), 64)
+
+	var manyKeys values.Object
+	var manyExpect strings.Builder
+	manyExpect.WriteString("f8fa")
+	for index := 0; index < 70; index++ {
+		key := fmt.Sprintf("k%d", index)
+		manyKeys = append(manyKeys, values.ObjectField{Key: key, Value: values.Int(0)})
+		manyExpect.WriteString(hex.EncodeToString(append([]byte{0x80 | byte(len(key)-1)}, key...)))
+		manyExpect.WriteString("c0")
+	}
+	manyExpect.WriteString("fbfa")
+	for index := 0; index < 70; index++ {
+		if index < 64 {
+			fmt.Fprintf(&manyExpect, "%02x", 0x40|index)
+		} else {
+			fmt.Fprintf(&manyExpect, "30%02x", index)
+		}
+		manyExpect.WriteString("c0")
+	}
+	manyExpect.WriteString("fbf9")
+
+	testData := [...]testCase{
+		{Name: "Header", Input: values.Null{}, Factory: plain, Expect: "3a290a01" + "21"},
+		{Name: "Header/AllFlags", Input: values.Null{}, Factory: Smile{SharedValues: true, RawBinary: true}, Expect: "3a290a07" + "21"},
+		{Name: "Header/NoSharing", Input: values.Null{}, Factory: Smile{DisableSharedNames: true}, Expect: "3a290a00" + "21"},
+		{Name: "Bool", Input: values.Array{values.Bool(false), values.Bool(true)}, Factory: bare, Expect: "f82223f9"},
+		{Name: "Int/Small", Input: values.Array{values.Int(0), values.Int(-1), values.Int(15), values.Int(-16)}, Factory: bare, Expect: "f8c0c1dedff9"},
+		{Name: "Int/32", Input: values.Int(100), Factory: bare, Expect: "240388"},
+		{Name: "Int/64", Input: values.Int(1 << 40), Factory: bare, Expect: "25" + "01000000000080"},
+		{Name: "BigInt", Input: values.BigIntValue{Pointer: huge}, Factory: bare, Expect: "26" + "89" + "0040000000000000" + "000000"},
+		{Name: "BigDecimal", Input: values.BigRatValue{Pointer: big.NewRat(1, 10)}, Factory: bare, Expect: "2a" + "82" + "81" + "0001"},
+		{Name: "Float/32", Input: values.Float(1.5), Factory: bare, Expect: "28" + "037e000000"},
+		{Name: "Float/64", Input: values.Float(0.1), Factory: bare, Expect: "29" + "003f5c6633194c66331a"},
+		{Name: "String/Empty", Input: values.String(""), Factory: bare, Expect: "20"},
+		{Name: "String/TinyASCII", Input: values.String("abc"), Factory: bare, Expect: "42616263"},
+		{Name: "String/SmallASCII", Input: values.String(strings.Repeat("x", 33)), Factory: bare, Expect: "60" + strings.Repeat("78", 33)},
+		{Name: "String/LongASCII", Input: values.String(strings.Repeat("x", 65)), Factory: bare, Expect: "e0" + strings.Repeat("78", 65) + "fc"},
+		{Name: "String/TinyUnicode", Input: values.String("é"), Factory: bare, Expect: "80c3a9"},
+		{Name: "Bytes/7Bit", Input: values.Bytes{0xff}, Factory: bare, Expect: "e8817f01"},
+		{Name: "Bytes/7BitChunk", Input: values.Bytes{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x80}, Factory: bare, Expect: "e888" + "7f7f7f7f7f7f7f7f" + "4000"},
+		{Name: "Bytes/Raw", Input: values.Bytes{0xff}, Factory: Smile{RawBinary: true}, Expect: "3a290a05" + "fd81ff"},
+		{
+			Name:    "SharedNames",
+			Input:   values.Array{values.Object{{Key: "a", Value: values.Int(1)}}, values.Object{{Key: "a", Value: values.Int(2)}}},
+			Factory: bare,
+			Expect:  "f8" + "fa" + "8061" + "c2" + "fb" + "fa" + "40" + "c4" + "fb" + "f9",
+		},
+		{
+			Name:    "SharedNames/Disabled",
+			Input:   values.Array{values.Object{{Key: "a", Value: values.Int(1)}}, values.Object{{Key: "a", Value: values.Int(2)}}},
+			Factory: Smile{OmitHeader: true, DisableSharedNames: true},
+			Expect:  "f8" + "fa" + "8061" + "c2" + "fb" + "fa" + "8061" + "c4" + "fb" + "f9",
+		},
+		{
+			Name:    "SharedNames/Long",
+			Input:   values.Array{manyKeys, manyKeys},
+			Factory: bare,
+			Expect:  manyExpect.String(),
+		},
+		{
+			Name:    "SharedValues",
+			Input:   values.Array{values.String("x"), values.String("y"), values.String("x")},
+			Factory: Smile{OmitHeader: true, SharedValues: true},
+			Expect:  "f8" + "4078" + "4079" + "01" + "f9",
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, row.Factory.NewGenerator())
+			row.Input.EmitTo(&e)
+			if err := e.Close(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if actual := hex.EncodeToString(buf.Bytes()); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %s\n\tactual: %s", row.Expect, actual)
+			}
+		})
+	}
+}
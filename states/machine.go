@@ -38,6 +38,34 @@ func (sm *Machine) Pop() {
 	sm.Stack = sm.Stack[:n]
 }
 
+func (sm *Machine) InKey() bool {
+	if sm.State.In(KeyRoot, KeyEnd) {
+		return true
+	}
+	for _, state := range sm.Stack {
+		if state.In(KeyRoot, KeyEnd) {
+			return true
+		}
+	}
+	return false
+}
+
+func (sm *Machine) StartKey() {
+	sm.ExpectKey()
+	sm.Push(KeyRoot)
+}
+
+func (sm *Machine) EndKey() {
+	sm.Expect(KeyEnd)
+	sm.Pop()
+	sm.Next()
+}
+
+func (sm *Machine) NextDocument() {
+	sm.ExpectEnd()
+	sm.State = Root
+}
+
 func (sm *Machine) Next() {
 	sm.State = sm.State.Next()
 }
@@ -55,7 +83,7 @@ func (sm *Machine) ExpectKey() {
 }
 
 func (sm *Machine) ExpectValue() {
-	sm.Expect(Root, ObjectFirstValue, ObjectNextValue, ArrayFirstValue, ArrayNextValue)
+	sm.Expect(Root, ObjectFirstValue, ObjectNextValue, ArrayFirstValue, ArrayNextValue, KeyRoot)
 }
 
 func (sm *Machine) ExpectArray() {
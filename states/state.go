@@ -15,9 +15,11 @@ const (
 	ArrayFirstValue
 	ArrayNextValue
 	End
+	KeyRoot
+	KeyEnd
 )
 
-const stateSize = 8
+const stateSize = 10
 
 var stateGoNames = [stateSize]string{
 	"states.Root",
@@ -28,6 +30,8 @@ var stateGoNames = [stateSize]string{
 	"states.ArrayFirstValue",
 	"states.ArrayNextValue",
 	"states.End",
+	"states.KeyRoot",
+	"states.KeyEnd",
 }
 
 var stateNames = [stateSize]string{
@@ -39,6 +43,8 @@ var stateNames = [stateSize]string{
 	"arrayFirstValue",
 	"arrayNextValue",
 	"end",
+	"keyRoot",
+	"keyEnd",
 }
 
 func (state State) IsValid() bool {
@@ -79,6 +85,9 @@ func (state State) Next() State {
 	case ArrayNextValue:
 		return ArrayNextValue
 
+	case KeyRoot:
+		return KeyEnd
+
 	default:
 		panic(state.Unexpected())
 	}
@@ -0,0 +1,2 @@
+// Package toml implements the TOML v1.0 format for Emitter.
+package toml
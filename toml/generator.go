@@ -0,0 +1,231 @@
+package toml
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+type Appender = appenders.Appender
+
+type Generator struct {
+	toml  TOML
+	sm    states.Machine
+	stack []*node
+	key   string
+}
+
+func (g *Generator) Reset() {
+	g.sm.Reset()
+	g.stack = g.stack[:0]
+	g.key = ""
+}
+
+func (g *Generator) Factory() emitter.GeneratorFactory {
+	return g.toml
+}
+
+func (g *Generator) Begin() []Appender {
+	g.sm.ExpectRoot()
+	return nil
+}
+
+func (g *Generator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+	return nil
+}
+
+func (g *Generator) StartObject() []Appender {
+	return g.startContainer(tableNode, states.ObjectFirstKey)
+}
+
+func (g *Generator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	return g.endContainer()
+}
+
+func (g *Generator) StartArray() []Appender {
+	return g.startContainer(arrayNode, states.ArrayFirstValue)
+}
+
+func (g *Generator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	return g.endContainer()
+}
+
+func (g *Generator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+	g.key = key
+	g.sm.Next()
+	return nil
+}
+
+func (g *Generator) NullValue() []Appender {
+	g.scalar("")
+	return g.fail(fmt.Errorf("TOML has no null value"))
+}
+
+func (g *Generator) BoolValue(value bool) []Appender {
+	return g.scalar(strconv.FormatBool(value))
+}
+
+func (g *Generator) IntValue(value int64) []Appender {
+	return g.scalar(strconv.FormatInt(value, 10))
+}
+
+func (g *Generator) UintValue(value uint64) []Appender {
+	if value > math.MaxInt64 {
+		g.scalar("")
+		return g.fail(fmt.Errorf("integer %d is out of range for TOML", value))
+	}
+	return g.IntValue(int64(value))
+}
+
+func (g *Generator) BigIntValue(value *big.Int) []Appender {
+	switch {
+	case value == nil:
+		return g.NullValue()
+	case value.IsInt64():
+		return g.IntValue(value.Int64())
+	}
+	g.scalar("")
+	return g.fail(fmt.Errorf("integer %v is out of range for TOML", value))
+}
+
+func (g *Generator) NaNValue() []Appender {
+	return g.scalar("nan")
+}
+
+func (g *Generator) InfValue(isNeg bool) []Appender {
+	if isNeg {
+		return g.scalar("-inf")
+	}
+	return g.scalar("inf")
+}
+
+func (g *Generator) FloatValue(value float64) []Appender {
+	switch {
+	case math.IsNaN(value):
+		return g.NaNValue()
+	case math.IsInf(value, 0):
+		return g.InfValue(value < 0)
+	}
+
+	str := strconv.FormatFloat(value, 'g', -1, 64)
+	if !strings.ContainsAny(str, ".eEn") {
+		str += ".0"
+	}
+	return g.scalar(str)
+}
+
+func (g *Generator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	f, _ := value.Float64()
+	return g.FloatValue(f)
+}
+
+func (g *Generator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if value.IsInt() {
+		return g.BigIntValue(value.Num())
+	}
+	f, _ := value.Float64()
+	return g.FloatValue(f)
+}
+
+func (g *Generator) ComplexValue(value complex128) []Appender {
+	var list []Appender
+	list = append(list, g.StartObject()...)
+	list = append(list, g.Key("re")...)
+	list = append(list, g.FloatValue(real(value))...)
+	list = append(list, g.Key("im")...)
+	list = append(list, g.FloatValue(imag(value))...)
+	list = append(list, g.EndObject()...)
+	return list
+}
+
+func (g *Generator) StringValue(value string) []Appender {
+	return g.scalar(string(appendString(nil, value)))
+}
+
+func (g *Generator) BytesValue(value []byte) []Appender {
+	return g.StringValue(base64.StdEncoding.EncodeToString(value))
+}
+
+func (g *Generator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *Generator) RuneValue(value rune) []Appender {
+	return g.StringValue(string(value))
+}
+
+func (g *Generator) TimeValue(value time.Time) []Appender {
+	return g.scalar(value.Format(time.RFC3339Nano))
+}
+
+func (g *Generator) startContainer(kind nodeKind, next states.State) []Appender {
+	g.sm.ExpectValue()
+	isRoot := g.sm.State.In(states.Root)
+
+	var list []Appender
+	if isRoot && kind != tableNode {
+		list = g.fail(fmt.Errorf("TOML requires a table at the top level"))
+	}
+
+	n := &node{kind: kind}
+	if !isRoot {
+		g.top().add(g.key, n)
+	}
+	g.sm.Push(next)
+	g.stack = append(g.stack, n)
+	return list
+}
+
+func (g *Generator) endContainer() []Appender {
+	last := len(g.stack) - 1
+	n := g.stack[last]
+	g.stack = g.stack[:last]
+	g.sm.Pop()
+	g.sm.Next()
+
+	if last > 0 || n.kind != tableNode {
+		return nil
+	}
+	return []Appender{appenders.LiteralBytes(renderTable(nil, n, nil, false))}
+}
+
+func (g *Generator) scalar(text string) []Appender {
+	g.sm.ExpectValue()
+	if g.sm.State.In(states.Root) {
+		g.sm.Next()
+		return g.fail(fmt.Errorf("TOML requires a table at the top level"))
+	}
+
+	g.top().add(g.key, &node{kind: scalarNode, text: text})
+	g.sm.Next()
+	return nil
+}
+
+func (g *Generator) fail(err error) []Appender {
+	return []Appender{appenders.Error{Err: err}}
+}
+
+func (g *Generator) top() *node {
+	return g.stack[len(g.stack)-1]
+}
+
+var _ emitter.Generator = (*Generator)(nil)
@@ -0,0 +1,186 @@
+package toml
+
+import (
+	"fmt"
+)
+
+type nodeKind byte
+
+const (
+	scalarNode nodeKind = iota
+	tableNode
+	arrayNode
+)
+
+type node struct {
+	kind   nodeKind
+	text   string
+	keys   []string
+	values []*node
+}
+
+func (n *node) add(key string, child *node) {
+	if n.kind == tableNode {
+		n.keys = append(n.keys, key)
+	}
+	n.values = append(n.values, child)
+}
+
+func (n *node) isArrayOfTables() bool {
+	if n.kind != arrayNode || len(n.values) <= 0 {
+		return false
+	}
+	for _, item := range n.values {
+		if item.kind != tableNode {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *node) needsHeader() bool {
+	return n.kind == tableNode || n.isArrayOfTables()
+}
+
+func renderTable(out []byte, t *node, path []string, isArrayItem bool) []byte {
+	hasPairs := false
+	for _, value := range t.values {
+		if !value.needsHeader() {
+			hasPairs = true
+			break
+		}
+	}
+
+	if len(path) > 0 && (hasPairs || isArrayItem || len(t.values) <= 0) {
+		if len(out) > 0 {
+			out = append(out, '\n')
+		}
+		if isArrayItem {
+			out = append(out, "[["...)
+			out = appendPath(out, path)
+			out = append(out, "]]\n"...)
+		} else {
+			out = append(out, '[')
+			out = appendPath(out, path)
+			out = append(out, "]\n"...)
+		}
+	}
+
+	for index, value := range t.values {
+		if value.needsHeader() {
+			continue
+		}
+		out = appendKey(out, t.keys[index])
+		out = append(out, " = "...)
+		out = appendInline(out, value)
+		out = append(out, '\n')
+	}
+
+	for index, value := range t.values {
+		if !value.needsHeader() {
+			continue
+		}
+		subpath := append(path[:len(path):len(path)], t.keys[index])
+		if value.kind == tableNode {
+			out = renderTable(out, value, subpath, false)
+			continue
+		}
+		for _, item := range value.values {
+			out = renderTable(out, item, subpath, true)
+		}
+	}
+	return out
+}
+
+func appendInline(out []byte, n *node) []byte {
+	switch n.kind {
+	case tableNode:
+		if len(n.values) <= 0 {
+			return append(out, "{}"...)
+		}
+		out = append(out, "{ "...)
+		for index, value := range n.values {
+			if index > 0 {
+				out = append(out, ", "...)
+			}
+			out = appendKey(out, n.keys[index])
+			out = append(out, " = "...)
+			out = appendInline(out, value)
+		}
+		return append(out, " }"...)
+
+	case arrayNode:
+		out = append(out, '[')
+		for index, value := range n.values {
+			if index > 0 {
+				out = append(out, ", "...)
+			}
+			out = appendInline(out, value)
+		}
+		return append(out, ']')
+
+	default:
+		return append(out, n.text...)
+	}
+}
+
+func appendPath(out []byte, path []string) []byte {
+	for index, key := range path {
+		if index > 0 {
+			out = append(out, '.')
+		}
+		out = appendKey(out, key)
+	}
+	return out
+}
+
+func appendKey(out []byte, key string) []byte {
+	if isBareKey(key) {
+		return append(out, key...)
+	}
+	return appendString(out, key)
+}
+
+func isBareKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, ch := range key {
+		switch {
+		case ch >= 'A' && ch <= 'Z':
+		case ch >= 'a' && ch <= 'z':
+		case ch >= '0' && ch <= '9':
+		case ch == '_' || ch == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func appendString(out []byte, str string) []byte {
+	out = append(out, '"')
+	for _, ch := range str {
+		switch {
+		case ch == '"':
+			out = append(out, `\"`...)
+		case ch == '\\':
+			out = append(out, `\\`...)
+		case ch == '\b':
+			out = append(out, `\b`...)
+		case ch == '\t':
+			out = append(out, `\t`...)
+		case ch == '\n':
+			out = append(out, `\n`...)
+		case ch == '\f':
+			out = append(out, `\f`...)
+		case ch == '\r':
+			out = append(out, `\r`...)
+		case ch < 0x20 || ch == 0x7f:
+			out = fmt.Appendf(out, `\u%04X`, ch)
+		default:
+			out = append(out, string(ch)...)
+		}
+	}
+	return append(out, '"')
+}
@@ -0,0 +1,15 @@
+package toml
+
+import (
+	"github.com/chronos-tachyon/go-emitter"
+)
+
+type TOML struct{}
+
+func (toml TOML) NewGenerator() emitter.Generator {
+	g := &Generator{toml: toml}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = TOML{}
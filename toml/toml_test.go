@@ -0,0 +1,116 @@
+package toml
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+func TestTOML(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Input     Value
+		Expect    string
+		ExpectErr error
+	}
+
+	testData := [...]testCase{
+		{
+			Name: "Fancy",
+			Input: values.Object{
+				{Key: "title", Value: values.String("TOML \"Example\"")},
+				{Key: "owner", Value: values.Object{
+					{Key: "name", Value: values.String("Tom")},
+					{Key: "dob", Value: values.Time(time.Date(1979, 5, 27, 7, 32, 0, 0, time.UTC))},
+				}},
+				{Key: "ratio", Value: values.Float(1)},
+				{Key: "database", Value: values.Object{
+					{Key: "ports", Value: values.Array{values.Int(8000), values.Int(8001)}},
+					{Key: "limits", Value: values.Object{
+						{Key: "max", Value: values.Float(math.Inf(1))},
+					}},
+					{Key: "mixed", Value: values.Array{
+						values.Int(1),
+						values.Object{{Key: "a", Value: values.Bool(true)}},
+					}},
+				}},
+				{Key: "servers", Value: values.Array{
+					values.Object{
+						{Key: "ip", Value: values.String("10.0.0.1")},
+						{Key: "tags", Value: values.Object{{Key: "role", Value: values.String("frontend")}}},
+					},
+					values.Object{
+						{Key: "ip", Value: values.String("10.0.0.2")},
+					},
+				}},
+				{Key: "key with spaces", Value: values.Object(nil)},
+			},
+			Expect: strings.Join([]string{
+				`title = "TOML \"Example\""`,
+				`ratio = 1.0`,
+				``,
+				`[owner]`,
+				`name = "Tom"`,
+				`dob = 1979-05-27T07:32:00Z`,
+				``,
+				`[database]`,
+				`ports = [8000, 8001]`,
+				`mixed = [1, { a = true }]`,
+				``,
+				`[database.limits]`,
+				`max = inf`,
+				``,
+				`[[servers]]`,
+				`ip = "10.0.0.1"`,
+				``,
+				`[servers.tags]`,
+				`role = "frontend"`,
+				``,
+				`[[servers]]`,
+				`ip = "10.0.0.2"`,
+				``,
+				`["key with spaces"]`,
+				``,
+			}, "\n"),
+		},
+		{
+			Name:      "Null",
+			Input:     values.Object{{Key: "a", Value: values.Null{}}},
+			ExpectErr: fmt.Errorf("TOML has no null value"),
+		},
+		{
+			Name:      "RootArray",
+			Input:     values.Array{values.Int(1)},
+			ExpectErr: fmt.Errorf("TOML requires a table at the top level"),
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, TOML{}.NewGenerator())
+			row.Input.EmitTo(&e)
+			err := e.Close()
+
+			if !reflect.DeepEqual(err, row.ExpectErr) {
+				t.Errorf("wrong error:\n\texpect: %#v\n\tactual: %#v", row.ExpectErr, err)
+				return
+			}
+
+			if actual := buf.String(); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect:\n%s\n\tactual:\n%s", row.Expect, actual)
+			}
+		})
+	}
+}
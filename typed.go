@@ -0,0 +1,219 @@
+package emitter
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+type ElementType byte
+
+const (
+	Int8Element ElementType = iota
+	Int16Element
+	Int32Element
+	Int64Element
+	Uint8Element
+	Uint16Element
+	Uint32Element
+	Uint64Element
+	Float32Element
+	Float64Element
+)
+
+const elementTypeSize = 10
+
+var elementTypeGoNames = [elementTypeSize]string{
+	"emitter.Int8Element",
+	"emitter.Int16Element",
+	"emitter.Int32Element",
+	"emitter.Int64Element",
+	"emitter.Uint8Element",
+	"emitter.Uint16Element",
+	"emitter.Uint32Element",
+	"emitter.Uint64Element",
+	"emitter.Float32Element",
+	"emitter.Float64Element",
+}
+
+var elementTypeNames = [elementTypeSize]string{
+	"int8",
+	"int16",
+	"int32",
+	"int64",
+	"uint8",
+	"uint16",
+	"uint32",
+	"uint64",
+	"float32",
+	"float64",
+}
+
+var elementTypeSizes = [elementTypeSize]uint{1, 2, 4, 8, 1, 2, 4, 8, 4, 8}
+
+func (t ElementType) IsValid() bool {
+	return t < elementTypeSize
+}
+
+// Size returns the width of one element in bytes.
+func (t ElementType) Size() uint {
+	if t.IsValid() {
+		return elementTypeSizes[t]
+	}
+	return 0
+}
+
+func (t ElementType) GoString() string {
+	if t.IsValid() {
+		return elementTypeGoNames[t]
+	}
+	return fmt.Sprintf("emitter.ElementType(%d)", uint(t))
+}
+
+func (t ElementType) String() string {
+	if t.IsValid() {
+		return elementTypeNames[t]
+	}
+	return fmt.Sprintf("%%!ERR[invalid emitter.ElementType %d]", uint(t))
+}
+
+var (
+	_ fmt.GoStringer = ElementType(0)
+	_ fmt.Stringer   = ElementType(0)
+)
+
+// TypedArray is an array whose elements all share one fixed-width numeric
+// type.  It wraps a Go slice without copying it.
+type TypedArray struct {
+	slice any
+	typ   ElementType
+	n     int
+}
+
+// NewTypedArray wraps a slice of type []int8, []int16, []int32, []int64,
+// []uint8, []uint16, []uint32, []uint64, []float32, or []float64.  It
+// returns false for any other type.
+func NewTypedArray(slice any) (TypedArray, bool) {
+	switch x := slice.(type) {
+	case []int8:
+		return TypedArray{slice: x, typ: Int8Element, n: len(x)}, true
+	case []int16:
+		return TypedArray{slice: x, typ: Int16Element, n: len(x)}, true
+	case []int32:
+		return TypedArray{slice: x, typ: Int32Element, n: len(x)}, true
+	case []int64:
+		return TypedArray{slice: x, typ: Int64Element, n: len(x)}, true
+	case []uint8:
+		return TypedArray{slice: x, typ: Uint8Element, n: len(x)}, true
+	case []uint16:
+		return TypedArray{slice: x, typ: Uint16Element, n: len(x)}, true
+	case []uint32:
+		return TypedArray{slice: x, typ: Uint32Element, n: len(x)}, true
+	case []uint64:
+		return TypedArray{slice: x, typ: Uint64Element, n: len(x)}, true
+	case []float32:
+		return TypedArray{slice: x, typ: Float32Element, n: len(x)}, true
+	case []float64:
+		return TypedArray{slice: x, typ: Float64Element, n: len(x)}, true
+	default:
+		return TypedArray{}, false
+	}
+}
+
+func (a TypedArray) Type() ElementType {
+	return a.typ
+}
+
+func (a TypedArray) Len() int {
+	return a.n
+}
+
+// Slice returns the wrapped slice.
+func (a TypedArray) Slice() any {
+	return a.slice
+}
+
+// Bits returns the raw bits of the element at index, zero-extended to 64
+// bits.  Signed integers are in two's complement, and floats are in IEEE 754
+// binary form.
+func (a TypedArray) Bits(index int) uint64 {
+	switch x := a.slice.(type) {
+	case []int8:
+		return uint64(uint8(x[index]))
+	case []int16:
+		return uint64(uint16(x[index]))
+	case []int32:
+		return uint64(uint32(x[index]))
+	case []int64:
+		return uint64(x[index])
+	case []uint8:
+		return uint64(x[index])
+	case []uint16:
+		return uint64(x[index])
+	case []uint32:
+		return uint64(x[index])
+	case []uint64:
+		return x[index]
+	case []float32:
+		return uint64(math.Float32bits(x[index]))
+	case []float64:
+		return math.Float64bits(x[index])
+	default:
+		panic(fmt.Errorf("index out of range [%d] with length 0", index))
+	}
+}
+
+// Append appends the elements to out in their native width, using the given
+// byte order.
+func (a TypedArray) Append(out []byte, order binary.AppendByteOrder) []byte {
+	for index := 0; index < a.n; index++ {
+		bits := a.Bits(index)
+		switch a.typ.Size() {
+		case 1:
+			out = append(out, byte(bits))
+		case 2:
+			out = order.AppendUint16(out, uint16(bits))
+		case 4:
+			out = order.AppendUint32(out, uint32(bits))
+		default:
+			out = order.AppendUint64(out, bits)
+		}
+	}
+	return out
+}
+
+func (a TypedArray) EmitTo(e *Emitter) {
+	e.EmitTypedArray(a)
+}
+
+// emitArray emits the elements as an ordinary array, one at a time.
+func (a TypedArray) emitArray(e *Emitter) {
+	e.StartArray()
+	for index := 0; index < a.n; index++ {
+		switch x := a.slice.(type) {
+		case []int8:
+			e.EmitInt8(x[index])
+		case []int16:
+			e.EmitInt16(x[index])
+		case []int32:
+			e.EmitInt32(x[index])
+		case []int64:
+			e.EmitInt64(x[index])
+		case []uint8:
+			e.EmitUint8(x[index])
+		case []uint16:
+			e.EmitUint16(x[index])
+		case []uint32:
+			e.EmitUint32(x[index])
+		case []uint64:
+			e.EmitUint64(x[index])
+		case []float32:
+			e.EmitFloat32(x[index])
+		case []float64:
+			e.EmitFloat64(x[index])
+		}
+	}
+	e.EndArray()
+}
+
+var _ Value = TypedArray{}
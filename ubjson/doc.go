@@ -0,0 +1,3 @@
+// Package ubjson implements Universal Binary JSON (Draft 12) and its Binary
+// JData extension for Emitter.
+package ubjson
@@ -0,0 +1,193 @@
+package ubjson
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/chronos-tachyon/go-emitter"
+)
+
+const (
+	markerNull          = 'Z'
+	markerTrue          = 'T'
+	markerFalse         = 'F'
+	markerInt8          = 'i'
+	markerUint8         = 'U'
+	markerInt16         = 'I'
+	markerInt32         = 'l'
+	markerInt64         = 'L'
+	markerUint16        = 'u'
+	markerUint32        = 'm'
+	markerUint64        = 'M'
+	markerFloat32       = 'd'
+	markerFloat64       = 'D'
+	markerHighPrecision = 'H'
+	markerChar          = 'C'
+	markerString        = 'S'
+	markerArrayStart    = '['
+	markerArrayEnd      = ']'
+	markerObjectStart   = '{'
+	markerObjectEnd     = '}'
+	markerType          = '$'
+	markerCount         = '#'
+)
+
+// typedMarkers maps each element type to the marker used for it in an
+// optimized container.  A zero entry means the type has no exact marker.
+var typedMarkers = [2][10]byte{
+	{
+		emitter.Int8Element:    markerInt8,
+		emitter.Int16Element:   markerInt16,
+		emitter.Int32Element:   markerInt32,
+		emitter.Int64Element:   markerInt64,
+		emitter.Uint8Element:   markerUint8,
+		emitter.Float32Element: markerFloat32,
+		emitter.Float64Element: markerFloat64,
+	},
+	{
+		emitter.Int8Element:    markerInt8,
+		emitter.Int16Element:   markerInt16,
+		emitter.Int32Element:   markerInt32,
+		emitter.Int64Element:   markerInt64,
+		emitter.Uint8Element:   markerUint8,
+		emitter.Uint16Element:  markerUint16,
+		emitter.Uint32Element:  markerUint32,
+		emitter.Uint64Element:  markerUint64,
+		emitter.Float32Element: markerFloat32,
+		emitter.Float64Element: markerFloat64,
+	},
+}
+
+type encoder struct {
+	order  binary.AppendByteOrder
+	bjdata bool
+}
+
+func (enc encoder) appendInt(out []byte, value int64) []byte {
+	switch {
+	case value >= 0:
+		out, _ = enc.appendUint(out, uint64(value))
+		return out
+	case value >= math.MinInt8:
+		return append(out, markerInt8, byte(value))
+	case value >= math.MinInt16:
+		return enc.order.AppendUint16(append(out, markerInt16), uint16(value))
+	case value >= math.MinInt32:
+		return enc.order.AppendUint32(append(out, markerInt32), uint32(value))
+	default:
+		return enc.order.AppendUint64(append(out, markerInt64), uint64(value))
+	}
+}
+
+// appendUint writes value using the smallest integer type that holds it.  It
+// returns false, leaving out unchanged, if no integer type is wide enough.
+func (enc encoder) appendUint(out []byte, value uint64) ([]byte, bool) {
+	switch {
+	case value <= math.MaxUint8:
+		return append(out, markerUint8, byte(value)), true
+	case value <= math.MaxInt16:
+		return enc.order.AppendUint16(append(out, markerInt16), uint16(value)), true
+	case enc.bjdata && value <= math.MaxUint16:
+		return enc.order.AppendUint16(append(out, markerUint16), uint16(value)), true
+	case value <= math.MaxInt32:
+		return enc.order.AppendUint32(append(out, markerInt32), uint32(value)), true
+	case enc.bjdata && value <= math.MaxUint32:
+		return enc.order.AppendUint32(append(out, markerUint32), uint32(value)), true
+	case value <= math.MaxInt64:
+		return enc.order.AppendUint64(append(out, markerInt64), value), true
+	case enc.bjdata:
+		return enc.order.AppendUint64(append(out, markerUint64), value), true
+	default:
+		return out, false
+	}
+}
+
+func (enc encoder) appendLength(out []byte, n int) []byte {
+	out, _ = enc.appendUint(out, uint64(n))
+	return out
+}
+
+func (enc encoder) appendFloat(out []byte, value float64) []byte {
+	if f32 := float32(value); float64(f32) == value || math.IsNaN(value) {
+		return enc.order.AppendUint32(append(out, markerFloat32), math.Float32bits(f32))
+	}
+	return enc.order.AppendUint64(append(out, markerFloat64), math.Float64bits(value))
+}
+
+func (enc encoder) appendKey(out []byte, key string) []byte {
+	out = enc.appendLength(out, len(key))
+	return append(out, key...)
+}
+
+func (enc encoder) appendString(out []byte, marker byte, str string) []byte {
+	out = append(out, marker)
+	return enc.appendKey(out, str)
+}
+
+// appendTypedHeader writes the start of an optimized array of count elements
+// of the given type.  Optimized arrays have no end marker.
+func (enc encoder) appendTypedHeader(out []byte, marker byte, count int) []byte {
+	out = append(out, markerArrayStart, markerType, marker, markerCount)
+	return enc.appendLength(out, count)
+}
+
+// typedMarker returns the optimized container marker for the elements of
+// value, and the element type they must be written as.
+func (enc encoder) typedMarker(value emitter.TypedArray) (byte, emitter.ElementType, bool) {
+	typ := value.Type()
+	if enc.bjdata {
+		return typedMarkers[1][typ], typ, true
+	}
+
+	// UBJSON lacks wider unsigned types, so widen to the next signed type.
+	switch typ {
+	case emitter.Uint16Element:
+		return markerInt32, emitter.Int32Element, true
+	case emitter.Uint32Element:
+		return markerInt64, emitter.Int64Element, true
+	case emitter.Uint64Element:
+		for index := 0; index < value.Len(); index++ {
+			if value.Bits(index) > math.MaxInt64 {
+				return 0, 0, false
+			}
+		}
+		return markerInt64, emitter.Int64Element, true
+	case emitter.Float32Element, emitter.Float64Element:
+		// UBJSON writes NaN and infinities as null, which a typed array
+		// cannot hold.
+		for index := 0; index < value.Len(); index++ {
+			if f := elementFloat(value, index); math.IsNaN(f) || math.IsInf(f, 0) {
+				return 0, 0, false
+			}
+		}
+		return typedMarkers[0][typ], typ, true
+	default:
+		return typedMarkers[0][typ], typ, true
+	}
+}
+
+// elementFloat returns the element of a Float32Element or Float64Element
+// typed array at index.
+func elementFloat(value emitter.TypedArray, index int) float64 {
+	bits := value.Bits(index)
+	if value.Type() == emitter.Float32Element {
+		return float64(math.Float32frombits(uint32(bits)))
+	}
+	return math.Float64frombits(bits)
+}
+
+func (enc encoder) appendElements(out []byte, value emitter.TypedArray, typ emitter.ElementType) []byte {
+	if typ == value.Type() {
+		return value.Append(out, enc.order)
+	}
+	for index := 0; index < value.Len(); index++ {
+		bits := value.Bits(index)
+		switch typ.Size() {
+		case 4:
+			out = enc.order.AppendUint32(out, uint32(bits))
+		default:
+			out = enc.order.AppendUint64(out, bits)
+		}
+	}
+	return out
+}
@@ -0,0 +1,240 @@
+package ubjson
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+type Appender = appenders.Appender
+
+type Generator struct {
+	factory emitter.GeneratorFactory
+	enc     encoder
+	sm      states.Machine
+}
+
+func (g *Generator) Reset() {
+	g.sm.Reset()
+}
+
+func (g *Generator) Factory() emitter.GeneratorFactory {
+	return g.factory
+}
+
+func (g *Generator) Begin() []Appender {
+	g.sm.ExpectRoot()
+	return nil
+}
+
+func (g *Generator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+	return nil
+}
+
+func (g *Generator) NextDocument() []Appender {
+	g.sm.NextDocument()
+	return nil
+}
+
+func (g *Generator) StartObject() []Appender {
+	g.sm.ExpectValue()
+	g.sm.Push(states.ObjectFirstKey)
+	return []Appender{appenders.LiteralBytes{markerObjectStart}}
+}
+
+func (g *Generator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	g.sm.Pop()
+	g.sm.Next()
+	return []Appender{appenders.LiteralBytes{markerObjectEnd}}
+}
+
+func (g *Generator) StartArray() []Appender {
+	g.sm.ExpectValue()
+	g.sm.Push(states.ArrayFirstValue)
+	return []Appender{appenders.LiteralBytes{markerArrayStart}}
+}
+
+func (g *Generator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	g.sm.Pop()
+	g.sm.Next()
+	return []Appender{appenders.LiteralBytes{markerArrayEnd}}
+}
+
+func (g *Generator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+	g.sm.Next()
+	return []Appender{appenders.LiteralBytes(g.enc.appendKey(nil, key))}
+}
+
+func (g *Generator) NullValue() []Appender {
+	return g.value([]byte{markerNull})
+}
+
+func (g *Generator) BoolValue(value bool) []Appender {
+	if value {
+		return g.value([]byte{markerTrue})
+	}
+	return g.value([]byte{markerFalse})
+}
+
+func (g *Generator) IntValue(value int64) []Appender {
+	return g.value(g.enc.appendInt(nil, value))
+}
+
+func (g *Generator) UintValue(value uint64) []Appender {
+	return g.value(g.appendUint(nil, value))
+}
+
+func (g *Generator) BigIntValue(value *big.Int) []Appender {
+	switch {
+	case value == nil:
+		return g.NullValue()
+	case value.IsInt64():
+		return g.IntValue(value.Int64())
+	case value.IsUint64():
+		return g.UintValue(value.Uint64())
+	default:
+		return g.value(g.enc.appendString(nil, markerHighPrecision, value.String()))
+	}
+}
+
+func (g *Generator) NaNValue() []Appender {
+	return g.FloatValue(math.NaN())
+}
+
+func (g *Generator) InfValue(isNeg bool) []Appender {
+	if isNeg {
+		return g.FloatValue(math.Inf(-1))
+	}
+	return g.FloatValue(math.Inf(1))
+}
+
+func (g *Generator) FloatValue(value float64) []Appender {
+	if !g.enc.bjdata && (math.IsNaN(value) || math.IsInf(value, 0)) {
+		// UBJSON requires these to be written as null.
+		return g.NullValue()
+	}
+	return g.value(g.enc.appendFloat(nil, value))
+}
+
+func (g *Generator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+
+	f, acc := value.Float64()
+	if acc == big.Exact || value.IsInf() {
+		return g.FloatValue(f)
+	}
+	return g.value(g.enc.appendString(nil, markerHighPrecision, value.Text('g', -1)))
+}
+
+func (g *Generator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if value.IsInt() {
+		return g.BigIntValue(value.Num())
+	}
+
+	f, exact := value.Float64()
+	if exact {
+		return g.FloatValue(f)
+	}
+	if _, ok := appenders.TerminatingDigits(value); ok {
+		str := string(appenders.BigRatDecimalText{Pointer: value}.Append(nil))
+		return g.value(g.enc.appendString(nil, markerHighPrecision, str))
+	}
+	return g.FloatValue(f)
+}
+
+func (g *Generator) ComplexValue(value complex128) []Appender {
+	typed, _ := emitter.NewTypedArray([]float64{real(value), imag(value)})
+	return g.TypedArrayValue(typed)
+}
+
+func (g *Generator) StringValue(value string) []Appender {
+	return g.value(g.enc.appendString(nil, markerString, value))
+}
+
+func (g *Generator) BytesValue(value []byte) []Appender {
+	out := g.enc.appendTypedHeader(nil, markerUint8, len(value))
+	out = append(out, value...)
+	return g.value(out)
+}
+
+func (g *Generator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *Generator) RuneValue(value rune) []Appender {
+	if value >= 0 && value < utf8.RuneSelf {
+		return g.value([]byte{markerChar, byte(value)})
+	}
+	return g.StringValue(string(value))
+}
+
+func (g *Generator) TimeValue(value time.Time) []Appender {
+	return g.StringValue(value.Format(time.RFC3339Nano))
+}
+
+func (g *Generator) TypedArrayValue(value emitter.TypedArray) []Appender {
+	marker, typ, ok := g.enc.typedMarker(value)
+	if !ok {
+		out := []byte{markerArrayStart}
+		for index := 0; index < value.Len(); index++ {
+			out = g.appendElement(out, value, index)
+		}
+		return g.value(append(out, markerArrayEnd))
+	}
+
+	out := g.enc.appendTypedHeader(nil, marker, value.Len())
+	out = g.enc.appendElements(out, value, typ)
+	return g.value(out)
+}
+
+// appendElement writes one element of a typed array that cannot use the
+// optimized container form.
+func (g *Generator) appendElement(out []byte, value emitter.TypedArray, index int) []byte {
+	switch value.Type() {
+	case emitter.Float32Element, emitter.Float64Element:
+		f := elementFloat(value, index)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return append(out, markerNull)
+		}
+		return g.enc.appendFloat(out, f)
+	default:
+		return g.appendUint(out, value.Bits(index))
+	}
+}
+
+// appendUint writes an integer, falling back to a high-precision number if
+// it is too large for any integer type.
+func (g *Generator) appendUint(out []byte, value uint64) []byte {
+	if out, ok := g.enc.appendUint(out, value); ok {
+		return out
+	}
+	return g.enc.appendString(out, markerHighPrecision, strconv.FormatUint(value, 10))
+}
+
+func (g *Generator) value(data []byte) []Appender {
+	g.sm.ExpectValue()
+	g.sm.Next()
+	return []Appender{appenders.LiteralBytes(data)}
+}
+
+var (
+	_ emitter.Generator           = (*Generator)(nil)
+	_ emitter.StreamGenerator     = (*Generator)(nil)
+	_ emitter.TypedArrayGenerator = (*Generator)(nil)
+)
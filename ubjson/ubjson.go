@@ -0,0 +1,27 @@
+package ubjson
+
+import (
+	"encoding/binary"
+
+	"github.com/chronos-tachyon/go-emitter"
+)
+
+type UBJSON struct{}
+
+func (ubjson UBJSON) NewGenerator() emitter.Generator {
+	g := &Generator{factory: ubjson, enc: encoder{order: binary.BigEndian}}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = UBJSON{}
+
+type BJData struct{}
+
+func (bjdata BJData) NewGenerator() emitter.Generator {
+	g := &Generator{factory: bjdata, enc: encoder{order: binary.LittleEndian, bjdata: true}}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = BJData{}
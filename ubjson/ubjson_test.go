@@ -0,0 +1,104 @@
+package ubjson
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+func typed(slice any) Value {
+	value, ok := emitter.NewTypedArray(slice)
+	if !ok {
+		panic(slice)
+	}
+	return value
+}
+
+func TestUBJSON(t *testing.T) {
+	type testCase struct {
+		Name    string
+		Input   Value
+		Factory emitter.GeneratorFactory
+		Expect  string
+	}
+
+	ubjson := UBJSON{}
+	bjdata := BJData{}
+
+	huge, _ := new(big.Int).SetString("-100000000000000000000", 10)
+
+	testData := [...]testCase{
+		{Name: "UBJSON/Null", Input: values.Null{}, Factory: ubjson, Expect: "5a"},
+		{Name: "UBJSON/True", Input: values.Bool(true), Factory: ubjson, Expect: "54"},
+		{Name: "UBJSON/Int/1", Input: values.Int(1), Factory: ubjson, Expect: "5501"},
+		{Name: "UBJSON/Int/-1", Input: values.Int(-1), Factory: ubjson, Expect: "69ff"},
+		{Name: "UBJSON/Int/300", Input: values.Int(300), Factory: ubjson, Expect: "49012c"},
+		{Name: "UBJSON/Int/40000", Input: values.Int(40000), Factory: ubjson, Expect: "6c00009c40"},
+		{Name: "UBJSON/Uint/Max", Input: values.Uint(math.MaxUint64), Factory: ubjson, Expect: "485514" + hex.EncodeToString([]byte("18446744073709551615"))},
+		{Name: "UBJSON/BigInt", Input: values.BigIntValue{Pointer: huge}, Factory: ubjson, Expect: "485516" + hex.EncodeToString([]byte("-100000000000000000000"))},
+		{Name: "UBJSON/Float/1.5", Input: values.Float(1.5), Factory: ubjson, Expect: "643fc00000"},
+		{Name: "UBJSON/Float/1.1", Input: values.Float(1.1), Factory: ubjson, Expect: "443ff199999999999a"},
+		{Name: "UBJSON/Float/NaN", Input: values.Float(math.NaN()), Factory: ubjson, Expect: "5a"},
+		{Name: "UBJSON/String", Input: values.String("abc"), Factory: ubjson, Expect: "535503616263"},
+		{Name: "UBJSON/Char", Input: values.Rune('a'), Factory: ubjson, Expect: "4361"},
+		{Name: "UBJSON/Rune", Input: values.Rune('é'), Factory: ubjson, Expect: "535502c3a9"},
+		{Name: "UBJSON/Bytes", Input: values.Bytes{1, 2, 3}, Factory: ubjson, Expect: "5b24552355" + "03" + "010203"},
+		{Name: "UBJSON/Complex", Input: values.Complex(complex(1, 2)), Factory: ubjson, Expect: "5b24442355" + "02" + "3ff0000000000000" + "4000000000000000"},
+		{Name: "UBJSON/Typed/Int16", Input: typed([]int16{1, -2}), Factory: ubjson, Expect: "5b24492355" + "02" + "0001" + "fffe"},
+		{Name: "UBJSON/Typed/Uint16", Input: typed([]uint16{1, 40000}), Factory: ubjson, Expect: "5b246c2355" + "02" + "00000001" + "00009c40"},
+		{Name: "UBJSON/Typed/Uint64", Input: typed([]uint64{1, 1 << 63}), Factory: ubjson, Expect: "5b" + "5501" + "485513" + hex.EncodeToString([]byte("9223372036854775808")) + "5d"},
+		{Name: "UBJSON/Typed/Float32", Input: typed([]float32{1.5}), Factory: ubjson, Expect: "5b24642355" + "01" + "3fc00000"},
+		{Name: "UBJSON/Typed/NaN", Input: typed([]float32{1.5, float32(math.NaN())}), Factory: ubjson, Expect: "5b" + "643fc00000" + "5a" + "5d"},
+		{Name: "UBJSON/Typed/Inf", Input: typed([]float64{1.1, math.Inf(1)}), Factory: ubjson, Expect: "5b" + "443ff199999999999a" + "5a" + "5d"},
+		{Name: "UBJSON/Typed/Empty", Input: typed([]float64(nil)), Factory: ubjson, Expect: "5b24442355" + "00"},
+		{
+			Name: "UBJSON/Object",
+			Input: values.Object{
+				{Key: "a", Value: values.Int(1)},
+				{Key: "b", Value: values.Array{values.Bool(true), typed([]int8{-1})}},
+			},
+			Factory: ubjson,
+			Expect:  "7b" + "550161" + "5501" + "550162" + "5b" + "54" + "5b24692355" + "01" + "ff" + "5d" + "7d",
+		},
+		{Name: "BJData/Int/40000", Input: values.Int(40000), Factory: bjdata, Expect: "75409c"},
+		{Name: "BJData/Int/3000000000", Input: values.Int(3000000000), Factory: bjdata, Expect: "6d005ed0b2"},
+		{Name: "BJData/Int/-300", Input: values.Int(-300), Factory: bjdata, Expect: "49d4fe"},
+		{Name: "BJData/Uint/Max", Input: values.Uint(math.MaxUint64), Factory: bjdata, Expect: "4dffffffffffffffff"},
+		{Name: "BJData/Float/1.5", Input: values.Float(1.5), Factory: bjdata, Expect: "640000c03f"},
+		{Name: "BJData/Float/Inf", Input: values.Float(math.Inf(-1)), Factory: bjdata, Expect: "64000080ff"},
+		{Name: "BJData/Typed/Int16", Input: typed([]int16{1, -2}), Factory: bjdata, Expect: "5b24492355" + "02" + "0100" + "feff"},
+		{Name: "BJData/Typed/Uint16", Input: typed([]uint16{1, 40000}), Factory: bjdata, Expect: "5b24752355" + "02" + "0100" + "409c"},
+		{Name: "BJData/Typed/Uint64", Input: typed([]uint64{1 << 63}), Factory: bjdata, Expect: "5b244d2355" + "01" + "0000000000000080"},
+		{
+			Name:    "BJData/Object",
+			Input:   values.Object{{Key: "v", Value: typed([]float32{1})}},
+			Factory: bjdata,
+			Expect:  "7b" + "550176" + "5b24642355" + "01" + "0000803f" + "7d",
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, row.Factory.NewGenerator())
+			row.Input.EmitTo(&e)
+			if err := e.Close(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if actual := hex.EncodeToString(buf.Bytes()); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect: %s\n\tactual: %s", row.Expect, actual)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package values
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/chronos-tachyon/go-emitter"
 )
@@ -70,6 +71,22 @@ func (v BigFloatValue) EmitTo(e *emitter.Emitter) {
 
 var _ emitter.Value = BigFloatValue{}
 
+type BigRatValue struct{ Pointer *big.Rat }
+
+func (v BigRatValue) EmitTo(e *emitter.Emitter) {
+	e.EmitBigRat(v.Pointer)
+}
+
+var _ emitter.Value = BigRatValue{}
+
+type Complex complex128
+
+func (v Complex) EmitTo(e *emitter.Emitter) {
+	e.EmitComplex128(complex128(v))
+}
+
+var _ emitter.Value = Complex(0)
+
 type String string
 
 func (v String) EmitTo(e *emitter.Emitter) {
@@ -102,6 +119,14 @@ func (v Rune) EmitTo(e *emitter.Emitter) {
 
 var _ emitter.Value = Rune('a')
 
+type Time time.Time
+
+func (v Time) EmitTo(e *emitter.Emitter) {
+	e.EmitTime(time.Time(v))
+}
+
+var _ emitter.Value = Time{}
+
 type Array []emitter.Value
 
 func (v Array) EmitTo(e *emitter.Emitter) {
@@ -131,3 +156,35 @@ func (v Object) EmitTo(e *emitter.Emitter) {
 }
 
 var _ emitter.Value = Object(nil)
+
+type MapEntry struct {
+	Key   emitter.Value
+	Value emitter.Value
+}
+
+type Map []MapEntry
+
+func (v Map) EmitTo(e *emitter.Emitter) {
+	e.StartObject()
+	for _, item := range v {
+		e.StartKey()
+		item.Key.EmitTo(e)
+		e.EndKey()
+		item.Value.EmitTo(e)
+	}
+	e.EndObject()
+}
+
+var _ emitter.Value = Map(nil)
+
+type Annotated struct {
+	Annotations []string
+	Value       emitter.Value
+}
+
+func (v Annotated) EmitTo(e *emitter.Emitter) {
+	e.Annotate(v.Annotations...)
+	v.Value.EmitTo(e)
+}
+
+var _ emitter.Value = Annotated{}
@@ -0,0 +1,140 @@
+package xml
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/chronos-tachyon/go-emitter/appenders"
+)
+
+type Appender = appenders.Appender
+
+type TextAppender struct {
+	Value       string
+	IsAttribute bool
+}
+
+func (a TextAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a TextAppender) Append(out []byte) []byte {
+	for _, ch := range a.Value {
+		switch {
+		case ch == '&':
+			out = append(out, "&amp;"...)
+		case ch == '<':
+			out = append(out, "&lt;"...)
+		case ch == '>':
+			out = append(out, "&gt;"...)
+		case ch == '"' && a.IsAttribute:
+			out = append(out, "&quot;"...)
+		case (ch == '\t' || ch == '\n' || ch == '\r') && a.IsAttribute:
+			out = fmt.Appendf(out, "&#x%X;", ch)
+		case ch == '\r':
+			out = append(out, "&#xD;"...)
+		case !isChar(ch):
+			out = utf8.AppendRune(out, unicode.ReplacementChar)
+		default:
+			out = utf8.AppendRune(out, ch)
+		}
+	}
+	return out
+}
+
+var (
+	_ fmt.Stringer = TextAppender{}
+	_ Appender     = TextAppender{}
+)
+
+// Name converts an arbitrary string into a valid XML element or attribute
+// name, replacing characters that are not allowed with underscores.
+func Name(str string) string {
+	var sb strings.Builder
+	for index, ch := range str {
+		switch {
+		case isNameStartChar(ch):
+			sb.WriteRune(ch)
+		case index > 0 && isNameChar(ch):
+			sb.WriteRune(ch)
+		case index == 0 && isNameChar(ch):
+			sb.WriteByte('_')
+			sb.WriteRune(ch)
+		default:
+			sb.WriteByte('_')
+		}
+	}
+
+	name := sb.String()
+	if name == "" || strings.HasPrefix(strings.ToLower(name), "xml") {
+		name = "_" + name
+	}
+	return name
+}
+
+func isChar(ch rune) bool {
+	switch {
+	case ch == '\t' || ch == '\n' || ch == '\r':
+		return true
+	case ch >= 0x20 && ch <= 0xd7ff:
+		return true
+	case ch >= 0xe000 && ch <= 0xfffd:
+		return true
+	case ch >= 0x10000 && ch <= 0x10ffff:
+		return true
+	default:
+		return false
+	}
+}
+
+func isNameStartChar(ch rune) bool {
+	switch {
+	case ch == '_':
+		return true
+	case ch >= 'A' && ch <= 'Z':
+		return true
+	case ch >= 'a' && ch <= 'z':
+		return true
+	case ch >= 0xc0 && ch <= 0x2ff && ch != 0xd7 && ch != 0xf7:
+		return true
+	case ch >= 0x370 && ch <= 0x1fff && ch != 0x37e:
+		return true
+	case ch >= 0x200c && ch <= 0x200d:
+		return true
+	case ch >= 0x2070 && ch <= 0x218f:
+		return true
+	case ch >= 0x2c00 && ch <= 0x2fef:
+		return true
+	case ch >= 0x3001 && ch <= 0xd7ff:
+		return true
+	case ch >= 0xf900 && ch <= 0xfdcf:
+		return true
+	case ch >= 0xfdf0 && ch <= 0xfffd:
+		return true
+	case ch >= 0x10000 && ch <= 0xeffff:
+		return true
+	default:
+		return false
+	}
+}
+
+func isNameChar(ch rune) bool {
+	switch {
+	case isNameStartChar(ch):
+		return true
+	case ch == '-' || ch == '.':
+		return true
+	case ch >= '0' && ch <= '9':
+		return true
+	case ch == 0xb7:
+		return true
+	case ch >= 0x300 && ch <= 0x36f:
+		return true
+	case ch >= 0x203f && ch <= 0x2040:
+		return true
+	default:
+		return false
+	}
+}
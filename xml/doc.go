@@ -0,0 +1,2 @@
+// Package xml implements several mappings of the Emitter data model onto XML.
+package xml
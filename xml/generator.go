@@ -0,0 +1,369 @@
+package xml
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+const jsonxNamespace = "http://www.ibm.com/xmlns/prod/2009/jsonx"
+
+const (
+	jsonxObject  = "json:object"
+	jsonxArray   = "json:array"
+	jsonxString  = "json:string"
+	jsonxNumber  = "json:number"
+	jsonxBoolean = "json:boolean"
+	jsonxNull    = "json:null"
+)
+
+type frame struct {
+	name     string
+	isArray  bool
+	tagOpen  bool
+	children bool
+	hasText  bool
+	attrs    map[string]struct{}
+}
+
+type Generator struct {
+	xml    XML
+	sm     states.Machine
+	frames []frame
+	key    string
+	isAttr bool
+	isText bool
+}
+
+func (g *Generator) Reset() {
+	g.sm.Reset()
+	g.frames = g.frames[:0]
+	g.key = ""
+	g.isAttr = false
+	g.isText = false
+}
+
+func (g *Generator) Factory() emitter.GeneratorFactory {
+	return g.xml
+}
+
+func (g *Generator) Begin() []Appender {
+	g.sm.ExpectRoot()
+	if !g.xml.Declaration {
+		return nil
+	}
+
+	var b appenders.Builder
+	b.AddString(`<?xml version="1.0" encoding="UTF-8"?>`)
+	g.xml.Format.LineFeed(&b)
+	return b.Build()
+}
+
+func (g *Generator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+
+	var b appenders.Builder
+	g.xml.Format.LineFeed(&b)
+	return b.Build()
+}
+
+func (g *Generator) StartObject() []Appender {
+	return g.startContainer(jsonxObject, false, states.ObjectFirstKey)
+}
+
+func (g *Generator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	return g.endContainer()
+}
+
+func (g *Generator) StartArray() []Appender {
+	return g.startContainer(jsonxArray, true, states.ArrayFirstValue)
+}
+
+func (g *Generator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	return g.endContainer()
+}
+
+func (g *Generator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+	g.key = key
+	if g.xml.Convention == Attributes {
+		switch {
+		case key == "#text":
+			g.isText = true
+		case strings.HasPrefix(key, "@") && g.top().tagOpen:
+			g.key = key[1:]
+			g.isAttr = true
+		case strings.HasPrefix(key, "@"):
+			g.key = key[1:]
+		}
+	}
+	g.sm.Next()
+	return nil
+}
+
+func (g *Generator) NullValue() []Appender {
+	return g.scalar(jsonxNull, "")
+}
+
+func (g *Generator) BoolValue(value bool) []Appender {
+	return g.scalar(jsonxBoolean, strconv.FormatBool(value))
+}
+
+func (g *Generator) IntValue(value int64) []Appender {
+	return g.scalar(jsonxNumber, strconv.FormatInt(value, 10))
+}
+
+func (g *Generator) UintValue(value uint64) []Appender {
+	return g.scalar(jsonxNumber, strconv.FormatUint(value, 10))
+}
+
+func (g *Generator) BigIntValue(value *big.Int) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	return g.scalar(jsonxNumber, value.String())
+}
+
+func (g *Generator) NaNValue() []Appender {
+	return g.special("NaN", "NaN")
+}
+
+func (g *Generator) InfValue(isNeg bool) []Appender {
+	if isNeg {
+		return g.special("-Inf", "-INF")
+	}
+	return g.special("+Inf", "INF")
+}
+
+func (g *Generator) FloatValue(value float64) []Appender {
+	return g.scalar(jsonxNumber, strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+func (g *Generator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if value.IsInf() {
+		return g.InfValue(value.Signbit())
+	}
+	return g.scalar(jsonxNumber, value.Text('g', -1))
+}
+
+func (g *Generator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if _, ok := appenders.TerminatingDigits(value); ok {
+		return g.scalar(jsonxNumber, string(appenders.BigRatDecimalText{Pointer: value}.Append(nil)))
+	}
+	return g.StringValue(value.String())
+}
+
+func (g *Generator) ComplexValue(value complex128) []Appender {
+	var list []Appender
+	list = append(list, g.StartObject()...)
+	list = append(list, g.Key("re")...)
+	list = append(list, g.floatValue(real(value))...)
+	list = append(list, g.Key("im")...)
+	list = append(list, g.floatValue(imag(value))...)
+	list = append(list, g.EndObject()...)
+	return list
+}
+
+func (g *Generator) StringValue(value string) []Appender {
+	return g.scalar(jsonxString, value)
+}
+
+func (g *Generator) BytesValue(value []byte) []Appender {
+	return g.StringValue(base64.StdEncoding.EncodeToString(value))
+}
+
+func (g *Generator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *Generator) RuneValue(value rune) []Appender {
+	return g.StringValue(string(value))
+}
+
+func (g *Generator) TimeValue(value time.Time) []Appender {
+	return g.StringValue(value.Format(time.RFC3339Nano))
+}
+
+func (g *Generator) floatValue(value float64) []Appender {
+	switch {
+	case math.IsNaN(value):
+		return g.NaNValue()
+	case math.IsInf(value, 0):
+		return g.InfValue(value < 0)
+	default:
+		return g.FloatValue(value)
+	}
+}
+
+func (g *Generator) special(jsonxText string, text string) []Appender {
+	if g.xml.Convention == JSONx {
+		return g.scalar(jsonxString, jsonxText)
+	}
+	return g.scalar(jsonxNumber, text)
+}
+
+func (g *Generator) startContainer(jsonxType string, isArray bool, next states.State) []Appender {
+	g.sm.ExpectValue()
+	g.isAttr = false
+	g.isText = false
+
+	var b appenders.Builder
+	name := g.openElement(&b, jsonxType)
+	g.sm.Push(next)
+	g.frames = append(g.frames, frame{name: name, isArray: isArray, tagOpen: true})
+	return b.Build()
+}
+
+func (g *Generator) endContainer() []Appender {
+	n := len(g.frames) - 1
+	f := g.frames[n]
+	g.frames = g.frames[:n]
+	g.sm.Pop()
+
+	var b appenders.Builder
+	switch {
+	case f.tagOpen:
+		b.AddString("/>")
+	case f.hasText:
+		g.closeElement(&b, f.name)
+	default:
+		if f.children {
+			g.indent(&b)
+		}
+		g.closeElement(&b, f.name)
+	}
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) scalar(jsonxType string, text string) []Appender {
+	g.sm.ExpectValue()
+
+	var b appenders.Builder
+	switch {
+	case g.isAttr:
+		name := Name(g.key)
+		f := g.top()
+		if _, found := f.attrs[name]; found {
+			b.Add(appenders.Error{Err: fmt.Errorf("XML element cannot repeat attribute %q", name)})
+			break
+		}
+		if f.attrs == nil {
+			f.attrs = make(map[string]struct{})
+		}
+		f.attrs[name] = struct{}{}
+		b.AddByte(' ')
+		b.AddString(name)
+		b.AddString(`="`)
+		b.Add(TextAppender{Value: text, IsAttribute: true})
+		b.AddByte('"')
+
+	case g.isText:
+		g.closeStartTag(&b)
+		g.top().hasText = true
+		b.Add(TextAppender{Value: text})
+
+	default:
+		name := g.openElement(&b, jsonxType)
+		if text == "" {
+			b.AddString("/>")
+			break
+		}
+		b.AddByte('>')
+		b.Add(TextAppender{Value: text})
+		g.closeElement(&b, name)
+	}
+
+	g.isAttr = false
+	g.isText = false
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) openElement(b *appenders.Builder, jsonxType string) string {
+	isRoot := g.sm.State.In(states.Root)
+	if !isRoot {
+		g.closeStartTag(b)
+		g.indent(b)
+	}
+
+	name := g.elementName(jsonxType)
+	b.AddByte('<')
+	b.AddString(name)
+
+	if g.xml.Convention != JSONx {
+		return name
+	}
+	if isRoot {
+		b.AddString(` xmlns:json="`)
+		b.AddString(jsonxNamespace)
+		b.AddByte('"')
+	}
+	if g.sm.State.In(states.ObjectFirstValue, states.ObjectNextValue) {
+		b.AddString(` name="`)
+		b.Add(TextAppender{Value: g.key, IsAttribute: true})
+		b.AddByte('"')
+	}
+	return name
+}
+
+func (g *Generator) closeElement(b *appenders.Builder, name string) {
+	b.AddString("</")
+	b.AddString(name)
+	b.AddByte('>')
+}
+
+func (g *Generator) closeStartTag(b *appenders.Builder) {
+	f := g.top()
+	if f.tagOpen {
+		b.AddByte('>')
+		f.tagOpen = false
+	}
+	f.children = true
+}
+
+func (g *Generator) elementName(jsonxType string) string {
+	switch {
+	case g.xml.Convention == JSONx:
+		return jsonxType
+	case g.sm.State.In(states.Root):
+		if g.xml.RootElement != "" {
+			return Name(g.xml.RootElement)
+		}
+		return "root"
+	case g.top().isArray:
+		if g.xml.ItemElement != "" {
+			return Name(g.xml.ItemElement)
+		}
+		return "item"
+	default:
+		return Name(g.key)
+	}
+}
+
+func (g *Generator) indent(b *appenders.Builder) {
+	g.xml.Format.Indent(b, g.xml.IndentWithTabs, g.xml.IndentSize, uint(len(g.frames)))
+}
+
+func (g *Generator) top() *frame {
+	return &g.frames[len(g.frames)-1]
+}
+
+var _ emitter.Generator = (*Generator)(nil)
@@ -0,0 +1,96 @@
+package xml
+
+import (
+	"encoding"
+	"fmt"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/json"
+)
+
+// XML generates XML documents using the mapping chosen by Convention.  In
+// the Elements and Attributes conventions, null and the empty string both
+// become an empty element, so they cannot be told apart when read back.
+type XML struct {
+	Convention     Convention
+	Format         json.Format
+	IndentSize     uint
+	IndentWithTabs bool
+	Declaration    bool
+	RootElement    string
+	ItemElement    string
+}
+
+func (xml XML) NewGenerator() emitter.Generator {
+	g := &Generator{xml: xml}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = XML{}
+
+type Convention byte
+
+const (
+	JSONx Convention = iota
+	Elements
+	Attributes
+)
+
+const conventionSize = 3
+
+var conventionGoNames = [conventionSize]string{
+	"xml.JSONx",
+	"xml.Elements",
+	"xml.Attributes",
+}
+
+var conventionNames = [conventionSize]string{
+	"jsonx",
+	"elements",
+	"attributes",
+}
+
+func (c Convention) IsValid() bool {
+	return c < conventionSize
+}
+
+func (c Convention) GoString() string {
+	if c.IsValid() {
+		return conventionGoNames[c]
+	}
+	return fmt.Sprintf("xml.Convention(%d)", uint(c))
+}
+
+func (c Convention) String() string {
+	if c.IsValid() {
+		return conventionNames[c]
+	}
+	return fmt.Sprintf("%%!ERR[invalid xml.Convention %d]", uint(c))
+}
+
+func (c Convention) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func (c *Convention) Parse(input string) error {
+	for index, name := range conventionNames {
+		if input == name {
+			*c = Convention(index)
+			return nil
+		}
+	}
+	*c = ^Convention(0)
+	return fmt.Errorf("failed to parse %q as xml.Convention", input)
+}
+
+func (c *Convention) UnmarshalText(input []byte) error {
+	return c.Parse(string(input))
+}
+
+var (
+	_ fmt.GoStringer           = Convention(0)
+	_ fmt.Stringer             = Convention(0)
+	_ encoding.TextMarshaler   = Convention(0)
+	_ encoding.TextUnmarshaler = (*Convention)(nil)
+)
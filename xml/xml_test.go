@@ -0,0 +1,117 @@
+package xml
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/json"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+func TestXML(t *testing.T) {
+	type testCase struct {
+		Name      string
+		Input     Value
+		Factory   emitter.GeneratorFactory
+		Expect    string
+		ExpectErr error
+	}
+
+	kFancyValue := values.Object{
+		{Key: "@id", Value: values.Int(7)},
+		{Key: "name", Value: values.String("a < b & \"c\"")},
+		{Key: "tags", Value: values.Array{values.String("x"), values.Null{}}},
+		{Key: "empty", Value: values.Object(nil)},
+		{Key: "ok", Value: values.Bool(true)},
+	}
+
+	jsonx := XML{}
+	elements := XML{Convention: Elements, ItemElement: "tag"}
+	attributes := XML{Convention: Attributes, Format: json.MultiLine, IndentSize: 2, Declaration: true}
+
+	testData := [...]testCase{
+		{
+			Name:    "JSONx/String",
+			Input:   values.String("x"),
+			Factory: jsonx,
+			Expect:  `<json:string xmlns:json="http://www.ibm.com/xmlns/prod/2009/jsonx">x</json:string>`,
+		},
+		{
+			Name:    "JSONx/Fancy",
+			Input:   kFancyValue,
+			Factory: jsonx,
+			Expect:  `<json:object xmlns:json="http://www.ibm.com/xmlns/prod/2009/jsonx"><json:number name="@id">7</json:number><json:string name="name">a &lt; b &amp; "c"</json:string><json:array name="tags"><json:string>x</json:string><json:null/></json:array><json:object name="empty"/><json:boolean name="ok">true</json:boolean></json:object>`,
+		},
+		{
+			Name:    "Elements/Fancy",
+			Input:   kFancyValue,
+			Factory: elements,
+			Expect:  `<root><_id>7</_id><name>a &lt; b &amp; "c"</name><tags><tag>x</tag><tag/></tags><empty/><ok>true</ok></root>`,
+		},
+		{
+			Name:    "Elements/Names",
+			Input:   values.Object{{Key: "1st key", Value: values.Float(0.5)}, {Key: "xmlns", Value: values.Bytes("abc")}},
+			Factory: XML{Convention: Elements, RootElement: "doc"},
+			Expect:  `<doc><_1st_key>0.5</_1st_key><_xmlns>YWJj</_xmlns></doc>`,
+		},
+		{
+			Name:    "Attributes/Fancy",
+			Input:   kFancyValue,
+			Factory: attributes,
+			Expect: "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n" +
+				"<root id=\"7\">\n" +
+				"  <name>a &lt; b &amp; \"c\"</name>\n" +
+				"  <tags>\n" +
+				"    <item>x</item>\n" +
+				"    <item/>\n" +
+				"  </tags>\n" +
+				"  <empty/>\n" +
+				"  <ok>true</ok>\n" +
+				"</root>\n",
+		},
+		{
+			Name: "Attributes/Text",
+			Input: values.Object{
+				{Key: "@lang", Value: values.String("en")},
+				{Key: "#text", Value: values.String("hello\r\n")},
+				{Key: "@late", Value: values.Bool(false)},
+			},
+			Factory: XML{Convention: Attributes},
+			Expect:  "<root lang=\"en\">hello&#xD;\n<late>false</late></root>",
+		},
+		{
+			Name: "Attributes/Duplicate",
+			Input: values.Object{
+				{Key: "@lang", Value: values.String("en")},
+				{Key: "@lang", Value: values.String("fr")},
+			},
+			Factory:   XML{Convention: Attributes},
+			ExpectErr: fmt.Errorf("XML element cannot repeat attribute %q", "lang"),
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, row.Factory.NewGenerator())
+			row.Input.EmitTo(&e)
+			err := e.Close()
+
+			if !reflect.DeepEqual(err, row.ExpectErr) {
+				t.Errorf("wrong error:\n\texpect: %#v\n\tactual: %#v", row.ExpectErr, err)
+				return
+			}
+
+			if actual := buf.String(); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect:\n%s\n\tactual:\n%s", row.Expect, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,374 @@
+package yaml
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/chronos-tachyon/go-emitter/appenders"
+)
+
+type Appender = appenders.Appender
+
+type StringAppender struct {
+	Value string
+}
+
+func (a StringAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a StringAppender) Append(out []byte) []byte {
+	switch {
+	case isPlain(a.Value):
+		return append(out, a.Value...)
+	case isSingleQuotable(a.Value):
+		return appendSingleQuoted(out, a.Value)
+	default:
+		return appendDoubleQuoted(out, a.Value)
+	}
+}
+
+var (
+	_ fmt.Stringer = StringAppender{}
+	_ Appender     = StringAppender{}
+)
+
+type BlockStringAppender struct {
+	Value  string
+	Indent uint
+	Folded bool
+	Width  uint
+}
+
+func (a BlockStringAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a BlockStringAppender) Append(out []byte) []byte {
+	body := strings.TrimRight(a.Value, "\n")
+	trailing := len(a.Value) - len(body)
+	segments := strings.Split(body, "\n")
+
+	var lines []string
+	if a.Folded {
+		out = append(out, '>')
+		for index, segment := range segments {
+			if index > 0 {
+				lines = append(lines, "")
+			}
+			lines = appendWrapped(lines, segment, a.Width)
+		}
+	} else {
+		out = append(out, '|')
+		lines = segments
+	}
+
+	switch trailing {
+	case 0:
+		out = append(out, '-')
+	case 1:
+		// pass
+	default:
+		out = append(out, '+')
+	}
+
+	for _, line := range lines {
+		out = append(out, '\n')
+		if line != "" {
+			for i := uint(0); i < a.Indent; i++ {
+				out = append(out, ' ')
+			}
+			out = append(out, line...)
+		}
+	}
+	for i := 1; i < trailing; i++ {
+		out = append(out, '\n')
+	}
+	return out
+}
+
+var (
+	_ fmt.Stringer = BlockStringAppender{}
+	_ Appender     = BlockStringAppender{}
+)
+
+type BytesAppender struct {
+	Value []byte
+}
+
+func (a BytesAppender) String() string {
+	return string(a.Append(nil))
+}
+
+func (a BytesAppender) Append(out []byte) []byte {
+	out = append(out, "!!binary "...)
+	if len(a.Value) <= 0 {
+		return append(out, `""`...)
+	}
+	n := base64.StdEncoding.EncodedLen(len(a.Value))
+	out = append(out, make([]byte, n)...)
+	base64.StdEncoding.Encode(out[len(out)-n:], a.Value)
+	return out
+}
+
+var (
+	_ fmt.Stringer = BytesAppender{}
+	_ Appender     = BytesAppender{}
+)
+
+type FloatAppender float64
+
+func (a FloatAppender) Append(out []byte) []byte {
+	start := len(out)
+	out = strconv.AppendFloat(out, float64(a), 'g', -1, 64)
+	return ensureFloat(out, start)
+}
+
+var _ Appender = FloatAppender(0)
+
+type BigFloatAppender struct {
+	Pointer *big.Float
+}
+
+func (a BigFloatAppender) Append(out []byte) []byte {
+	if a.Pointer.IsInf() {
+		if a.Pointer.Signbit() {
+			return append(out, "-.inf"...)
+		}
+		return append(out, ".inf"...)
+	}
+	start := len(out)
+	out = a.Pointer.Append(out, 'g', -1)
+	return ensureFloat(out, start)
+}
+
+var _ Appender = BigFloatAppender{}
+
+func ensureFloat(out []byte, start int) []byte {
+	exp := -1
+	for i := start; i < len(out); i++ {
+		switch out[i] {
+		case '.':
+			return out
+		case 'e':
+			exp = i
+		}
+	}
+	if exp < 0 {
+		return append(out, ".0"...)
+	}
+	out = append(out, ".0"...)
+	copy(out[exp+2:], out[exp:len(out)-2])
+	out[exp], out[exp+1] = '.', '0'
+	return out
+}
+
+func isBlockable(str string) bool {
+	body := strings.TrimRight(str, "\n")
+	if body == "" {
+		return false
+	}
+
+	firstLine := true
+	for _, line := range strings.Split(body, "\n") {
+		if line == "" {
+			continue
+		}
+		if firstLine && (line[0] == ' ' || line[0] == '\t') {
+			return false
+		}
+		if strings.TrimLeft(line, " \t") == "" {
+			return false
+		}
+		for _, ch := range line {
+			if ch != '\t' && !isPrintable(ch) {
+				return false
+			}
+			if isLineBreak(ch) {
+				return false
+			}
+		}
+		firstLine = false
+	}
+	return true
+}
+
+func isFoldable(str string) bool {
+	body := strings.TrimRight(str, "\n")
+	for _, line := range strings.Split(body, "\n") {
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, " ") || strings.HasSuffix(line, " ") {
+			return false
+		}
+		if strings.Contains(line, "  ") || strings.ContainsRune(line, '\t') {
+			return false
+		}
+	}
+	return isBlockable(str)
+}
+
+func appendWrapped(lines []string, segment string, width uint) []string {
+	if segment == "" {
+		return lines
+	}
+
+	var line string
+	for _, word := range strings.Split(segment, " ") {
+		switch {
+		case line == "":
+			line = word
+		case width > 0 && uint(len(line)+1+len(word)) > width:
+			lines = append(lines, line)
+			line = word
+		default:
+			line = line + " " + word
+		}
+	}
+	return append(lines, line)
+}
+
+func isPlain(str string) bool {
+	if str == "" {
+		return false
+	}
+
+	if _, found := reservedWords[strings.ToLower(str)]; found {
+		return false
+	}
+
+	first, _ := utf8.DecodeRuneInString(str)
+	if first != '_' && first != '/' && !unicode.IsLetter(first) {
+		return false
+	}
+
+	last, _ := utf8.DecodeLastRuneInString(str)
+	if last == ' ' || last == ':' {
+		return false
+	}
+
+	if strings.Contains(str, ": ") || strings.Contains(str, " #") {
+		return false
+	}
+
+	for _, ch := range str {
+		switch ch {
+		case '#', ',', '[', ']', '{', '}', '\t':
+			return false
+		}
+		if !isPrintable(ch) || isLineBreak(ch) {
+			return false
+		}
+	}
+	return true
+}
+
+func isSingleQuotable(str string) bool {
+	for _, ch := range str {
+		if ch == '\t' || !isPrintable(ch) || isLineBreak(ch) {
+			return false
+		}
+	}
+	return true
+}
+
+func appendSingleQuoted(out []byte, str string) []byte {
+	out = append(out, '\'')
+	for _, ch := range str {
+		if ch == '\'' {
+			out = append(out, '\'', '\'')
+			continue
+		}
+		out = utf8.AppendRune(out, ch)
+	}
+	return append(out, '\'')
+}
+
+func appendDoubleQuoted(out []byte, str string) []byte {
+	out = append(out, '"')
+	for _, ch := range str {
+		if esc, found := stringEscapes[ch]; found {
+			out = append(out, esc...)
+			continue
+		}
+
+		switch {
+		case ch < 0x100 && !isPrintable(ch):
+			out = fmt.Appendf(out, "\\x%02x", ch)
+		case ch < 0x10000 && !isPrintable(ch):
+			out = fmt.Appendf(out, "\\u%04x", ch)
+		case !isPrintable(ch):
+			out = fmt.Appendf(out, "\\U%08x", ch)
+		default:
+			out = utf8.AppendRune(out, ch)
+		}
+	}
+	return append(out, '"')
+}
+
+func isPrintable(ch rune) bool {
+	switch {
+	case ch == '\t' || ch == '\n' || ch == '\r':
+		return true
+	case ch >= 0x20 && ch <= 0x7e:
+		return true
+	case ch == 0x85:
+		return true
+	case ch >= 0xa0 && ch <= 0xd7ff:
+		return true
+	case ch >= 0xe000 && ch <= 0xfffd:
+		return ch != 0xfeff
+	case ch >= 0x10000 && ch <= 0x10ffff:
+		return true
+	default:
+		return false
+	}
+}
+
+func isLineBreak(ch rune) bool {
+	switch ch {
+	case '\n', '\r', 0x85, 0x2028, 0x2029:
+		return true
+	default:
+		return false
+	}
+}
+
+var reservedWords = map[string]struct{}{
+	"null":     {},
+	"true":     {},
+	"false":    {},
+	"y":        {},
+	"n":        {},
+	"yes":      {},
+	"no":       {},
+	"on":       {},
+	"off":      {},
+	"nan":      {},
+	"inf":      {},
+	"infinity": {},
+}
+
+var stringEscapes = map[rune]string{
+	'"':    `\"`,
+	'\\':   `\\`,
+	'\x00': `\0`,
+	'\a':   `\a`,
+	'\b':   `\b`,
+	'\t':   `\t`,
+	'\n':   `\n`,
+	'\v':   `\v`,
+	'\f':   `\f`,
+	'\r':   `\r`,
+	'\x1b': `\e`,
+	0x85:   `\N`,
+	0xa0:   `\_`,
+	0x2028: `\L`,
+	0x2029: `\P`,
+}
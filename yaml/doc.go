@@ -0,0 +1,2 @@
+// Package yaml implements the YAML 1.2 format for Emitter.
+package yaml
@@ -0,0 +1,324 @@
+package yaml
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/appenders"
+	"github.com/chronos-tachyon/go-emitter/states"
+)
+
+type lead byte
+
+const (
+	leadNone lead = iota
+	leadSpace
+	leadNewline
+)
+
+type frame struct {
+	col   uint
+	isSeq bool
+	first bool
+	lead  lead
+}
+
+type Generator struct {
+	yaml   YAML
+	sm     states.Machine
+	frames []frame
+}
+
+func (g *Generator) Reset() {
+	g.sm.Reset()
+	g.frames = g.frames[:0]
+}
+
+func (g *Generator) Factory() emitter.GeneratorFactory {
+	return g.yaml
+}
+
+func (g *Generator) Begin() []Appender {
+	g.sm.ExpectRoot()
+	if g.yaml.ExplicitStart {
+		return []Appender{appenders.LiteralString("---\n")}
+	}
+	return nil
+}
+
+func (g *Generator) End() []Appender {
+	g.sm.ExpectEnd()
+	g.sm.State = ^states.State(0)
+	return []Appender{appenders.LiteralString("\n")}
+}
+
+func (g *Generator) NextDocument() []Appender {
+	g.sm.NextDocument()
+	return []Appender{appenders.LiteralString("\n---\n")}
+}
+
+func (g *Generator) StartObject() []Appender {
+	return g.startContainer(false, states.ObjectFirstKey)
+}
+
+func (g *Generator) EndObject() []Appender {
+	g.sm.ExpectKey()
+	return g.endContainer('{', '}')
+}
+
+func (g *Generator) StartArray() []Appender {
+	return g.startContainer(true, states.ArrayFirstValue)
+}
+
+func (g *Generator) EndArray() []Appender {
+	g.sm.ExpectArray()
+	return g.endContainer('[', ']')
+}
+
+func (g *Generator) Key(key string) []Appender {
+	g.sm.ExpectKey()
+
+	var b appenders.Builder
+	g.startEntry(&b, g.top())
+	b.Add(StringAppender{Value: key})
+	b.AddByte(':')
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) NullValue() []Appender {
+	return g.literal(`null`)
+}
+
+func (g *Generator) BoolValue(value bool) []Appender {
+	if value {
+		return g.literal(`true`)
+	}
+	return g.literal(`false`)
+}
+
+func (g *Generator) IntValue(value int64) []Appender {
+	return g.value(appenders.IntText(value))
+}
+
+func (g *Generator) UintValue(value uint64) []Appender {
+	return g.value(appenders.UintText(value))
+}
+
+func (g *Generator) BigIntValue(value *big.Int) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	return g.value(appenders.BigIntText{Pointer: value})
+}
+
+func (g *Generator) NaNValue() []Appender {
+	return g.literal(`.nan`)
+}
+
+func (g *Generator) InfValue(isNeg bool) []Appender {
+	if isNeg {
+		return g.literal(`-.inf`)
+	}
+	return g.literal(`.inf`)
+}
+
+func (g *Generator) FloatValue(value float64) []Appender {
+	return g.value(FloatAppender(value))
+}
+
+func (g *Generator) BigFloatValue(value *big.Float) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	return g.value(BigFloatAppender{Pointer: value})
+}
+
+func (g *Generator) BigRatValue(value *big.Rat) []Appender {
+	if value == nil {
+		return g.NullValue()
+	}
+	if _, ok := appenders.TerminatingDigits(value); ok {
+		return g.value(appenders.BigRatDecimalText{Pointer: value})
+	}
+	str := string(appenders.BigRatText{Pointer: value}.Append(nil))
+	return g.StringValue(str)
+}
+
+func (g *Generator) ComplexValue(value complex128) []Appender {
+	var list []Appender
+	list = append(list, g.StartObject()...)
+	list = append(list, g.Key("re")...)
+	list = append(list, g.floatValue(real(value))...)
+	list = append(list, g.Key("im")...)
+	list = append(list, g.floatValue(imag(value))...)
+	list = append(list, g.EndObject()...)
+	return list
+}
+
+func (g *Generator) StringValue(value string) []Appender {
+	if g.yaml.Style == Block {
+		width := g.yaml.FoldWidth
+		indent := g.indentSize()
+		if n := uint(len(g.frames)); n > 0 {
+			indent += g.frames[n-1].col
+		}
+		switch {
+		case width > 0 && (len(value) > int(width) || strings.ContainsRune(value, '\n')) && isFoldable(value):
+			return g.value(BlockStringAppender{Value: value, Indent: indent, Folded: true, Width: width})
+		case strings.ContainsRune(value, '\n') && isBlockable(value):
+			return g.value(BlockStringAppender{Value: value, Indent: indent})
+		}
+	}
+	return g.value(StringAppender{Value: value})
+}
+
+func (g *Generator) BytesValue(value []byte) []Appender {
+	return g.value(BytesAppender{Value: value})
+}
+
+func (g *Generator) ByteValue(value byte) []Appender {
+	return g.RuneValue(rune(value))
+}
+
+func (g *Generator) RuneValue(value rune) []Appender {
+	return g.StringValue(string(value))
+}
+
+func (g *Generator) TimeValue(value time.Time) []Appender {
+	return g.value(appenders.LiteralString(value.Format(time.RFC3339Nano)))
+}
+
+func (g *Generator) floatValue(value float64) []Appender {
+	switch {
+	case math.IsNaN(value):
+		return g.NaNValue()
+	case math.IsInf(value, 0):
+		return g.InfValue(value < 0)
+	default:
+		return g.FloatValue(value)
+	}
+}
+
+func (g *Generator) startContainer(isSeq bool, next states.State) []Appender {
+	var b appenders.Builder
+	isRoot := g.beginValue(&b)
+	g.sm.Push(next)
+
+	f := frame{isSeq: isSeq, first: true}
+	switch {
+	case g.yaml.Style == Flow:
+		if !isRoot && !g.top().isSeq {
+			b.AddByte(' ')
+		}
+		if isSeq {
+			b.AddByte('[')
+		} else {
+			b.AddByte('{')
+		}
+	case isRoot:
+		f.lead = leadNone
+	case g.top().isSeq:
+		f.col = g.top().col + 2
+		f.lead = leadSpace
+	default:
+		f.col = g.top().col + g.indentSize()
+		f.lead = leadNewline
+	}
+	g.frames = append(g.frames, f)
+	return b.Build()
+}
+
+func (g *Generator) endContainer(open byte, close byte) []Appender {
+	f := *g.top()
+	g.frames = g.frames[:len(g.frames)-1]
+	g.sm.Pop()
+	isRoot := g.sm.State.In(states.Root)
+
+	var b appenders.Builder
+	switch {
+	case g.yaml.Style == Flow:
+		b.AddByte(close)
+	case f.first && isRoot:
+		b.AddByte(open)
+		b.AddByte(close)
+	case f.first:
+		b.AddByte(' ')
+		b.AddByte(open)
+		b.AddByte(close)
+	}
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) beginValue(b *appenders.Builder) bool {
+	g.sm.ExpectValue()
+	if g.sm.State.In(states.Root) {
+		return true
+	}
+
+	if f := g.top(); f.isSeq {
+		g.startEntry(b, f)
+		if g.yaml.Style == Block {
+			b.AddByte('-')
+		}
+	}
+	return false
+}
+
+func (g *Generator) startEntry(b *appenders.Builder, f *frame) {
+	if g.yaml.Style == Flow {
+		if !f.first {
+			b.AddString(", ")
+		}
+		f.first = false
+		return
+	}
+
+	l := leadNewline
+	if f.first {
+		l = f.lead
+	}
+	switch l {
+	case leadSpace:
+		b.AddByte(' ')
+	case leadNewline:
+		b.AddByte('\n')
+		b.Indent(false, 1, f.col)
+	}
+	f.first = false
+}
+
+func (g *Generator) value(a Appender) []Appender {
+	var b appenders.Builder
+	isRoot := g.beginValue(&b)
+	if !isRoot && (g.yaml.Style == Block || !g.top().isSeq) {
+		b.AddByte(' ')
+	}
+	b.Add(a)
+	g.sm.Next()
+	return b.Build()
+}
+
+func (g *Generator) literal(str string) []Appender {
+	return g.value(appenders.LiteralString(str))
+}
+
+func (g *Generator) top() *frame {
+	return &g.frames[len(g.frames)-1]
+}
+
+func (g *Generator) indentSize() uint {
+	if g.yaml.IndentSize <= 0 {
+		return 2
+	}
+	return g.yaml.IndentSize
+}
+
+var (
+	_ emitter.Generator       = (*Generator)(nil)
+	_ emitter.StreamGenerator = (*Generator)(nil)
+)
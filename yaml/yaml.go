@@ -0,0 +1,86 @@
+package yaml
+
+import (
+	"encoding"
+	"fmt"
+
+	"github.com/chronos-tachyon/go-emitter"
+)
+
+type YAML struct {
+	Style         Style
+	IndentSize    uint
+	FoldWidth     uint
+	ExplicitStart bool
+}
+
+func (yaml YAML) NewGenerator() emitter.Generator {
+	g := &Generator{yaml: yaml}
+	g.Reset()
+	return g
+}
+
+var _ emitter.GeneratorFactory = YAML{}
+
+type Style byte
+
+const (
+	Block Style = iota
+	Flow
+)
+
+const styleSize = 2
+
+var styleGoNames = [styleSize]string{
+	"yaml.Block",
+	"yaml.Flow",
+}
+
+var styleNames = [styleSize]string{
+	"block",
+	"flow",
+}
+
+func (s Style) IsValid() bool {
+	return s < styleSize
+}
+
+func (s Style) GoString() string {
+	if s.IsValid() {
+		return styleGoNames[s]
+	}
+	return fmt.Sprintf("yaml.Style(%d)", uint(s))
+}
+
+func (s Style) String() string {
+	if s.IsValid() {
+		return styleNames[s]
+	}
+	return fmt.Sprintf("%%!ERR[invalid yaml.Style %d]", uint(s))
+}
+
+func (s Style) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Style) Parse(input string) error {
+	for index, name := range styleNames {
+		if input == name {
+			*s = Style(index)
+			return nil
+		}
+	}
+	*s = ^Style(0)
+	return fmt.Errorf("failed to parse %q as yaml.Style", input)
+}
+
+func (s *Style) UnmarshalText(input []byte) error {
+	return s.Parse(string(input))
+}
+
+var (
+	_ fmt.GoStringer           = Style(0)
+	_ fmt.Stringer             = Style(0)
+	_ encoding.TextMarshaler   = Style(0)
+	_ encoding.TextUnmarshaler = (*Style)(nil)
+)
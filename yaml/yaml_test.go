@@ -0,0 +1,166 @@
+package yaml
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/chronos-tachyon/go-emitter"
+	"github.com/chronos-tachyon/go-emitter/values"
+)
+
+type Value = emitter.Value
+
+var kFancyValue Value = values.Object{
+	{Key: "name", Value: values.String("demo")},
+	{Key: "replicas", Value: values.Int(3)},
+	{Key: "enabled", Value: values.Bool(true)},
+	{Key: "ratio", Value: values.Float(2)},
+	{Key: "missing", Value: values.Null{}},
+	{Key: "tricky", Value: values.Array{
+		values.String("no"),
+		values.String("1e3"),
+		values.String("null"),
+		values.String("it's"),
+		values.String("a: b"),
+		values.String(""),
+	}},
+	{Key: "emptyList", Value: values.Array(nil)},
+	{Key: "emptyObject", Value: values.Object(nil)},
+	{Key: "items", Value: values.Array{
+		values.Object{
+			{Key: "a", Value: values.Int(1)},
+			{Key: "b", Value: values.Array{values.Int(2), values.Int(3)}},
+		},
+		values.Array{values.String("x"), values.String("y")},
+	}},
+	{Key: "script", Value: values.String("echo hi\necho bye\n")},
+	{Key: "data", Value: values.Bytes("abc")},
+	{Key: "special", Value: values.Array{
+		values.Float(math.NaN()),
+		values.Float(math.Inf(-1)),
+		values.String("tab\there"),
+	}},
+}
+
+func TestYAML(t *testing.T) {
+	type testCase struct {
+		Name    string
+		Input   []Value
+		Factory emitter.GeneratorFactory
+		Expect  string
+	}
+
+	testData := [...]testCase{
+		{
+			Name:    "Block/Scalar",
+			Input:   []Value{values.String("hello")},
+			Factory: YAML{},
+			Expect:  "hello\n",
+		},
+		{
+			Name:    "Block/Fancy",
+			Input:   []Value{kFancyValue},
+			Factory: YAML{},
+			Expect: ParseMultiLine(`
+			|name: demo
+			|replicas: 3
+			|enabled: true
+			|ratio: 2.0
+			|missing: null
+			|tricky:
+			|  - 'no'
+			|  - '1e3'
+			|  - 'null'
+			|  - it's
+			|  - 'a: b'
+			|  - ''
+			|emptyList: []
+			|emptyObject: {}
+			|items:
+			|  - a: 1
+			|    b:
+			|      - 2
+			|      - 3
+			|  - - x
+			|    - 'y'
+			|script: |
+			|  echo hi
+			|  echo bye
+			|data: !!binary YWJj
+			|special:
+			|  - .nan
+			|  - -.inf
+			|  - "tab\there"
+			`),
+		},
+		{
+			Name:    "Flow/Fancy",
+			Input:   []Value{kFancyValue},
+			Factory: YAML{Style: Flow},
+			Expect:  `{name: demo, replicas: 3, enabled: true, ratio: 2.0, missing: null, tricky: ['no', '1e3', 'null', it's, 'a: b', ''], emptyList: [], emptyObject: {}, items: [{a: 1, b: [2, 3]}, [x, 'y']], script: "echo hi\necho bye\n", data: !!binary YWJj, special: [.nan, -.inf, "tab\there"]}` + "\n",
+		},
+		{
+			Name: "Block/Folded",
+			Input: []Value{values.Object{
+				{Key: "text", Value: values.String("the quick brown fox jumps over the lazy dog\nagain")},
+			}},
+			Factory: YAML{FoldWidth: 20},
+			Expect: ParseMultiLine(`
+			|text: >-
+			|  the quick brown fox
+			|  jumps over the lazy
+			|  dog
+			|
+			|  again
+			`),
+		},
+		{
+			Name:    "Stream",
+			Input:   []Value{values.Object{{Key: "a", Value: values.Int(1)}}, values.Array{values.Int(2)}},
+			Factory: YAML{ExplicitStart: true},
+			Expect:  "---\na: 1\n---\n- 2\n",
+		},
+	}
+
+	var buf bytes.Buffer
+	var e emitter.Emitter
+	for _, row := range testData {
+		t.Run(row.Name, func(t *testing.T) {
+			buf.Reset()
+			e.Reset(&buf, row.Factory.NewGenerator())
+			for index, input := range row.Input {
+				if index > 0 {
+					e.NextDocument()
+				}
+				input.EmitTo(&e)
+			}
+			if err := e.Close(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if actual := buf.String(); actual != row.Expect {
+				t.Errorf("wrong result:\n\texpect:\n%s\n\tactual:\n%s", row.Expect, actual)
+			}
+		})
+	}
+}
+
+func ParseMultiLine(in string) string {
+	var sb strings.Builder
+	active := false
+	for _, ch := range in {
+		switch {
+		case active && ch == '\n':
+			sb.WriteByte('\n')
+			active = false
+		case active:
+			sb.WriteRune(ch)
+		case ch == '|':
+			active = true
+		}
+	}
+	return sb.String()
+}